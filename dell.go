@@ -2,18 +2,25 @@ package redfishapi
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
 	"os"
+	"path"
 	"regexp"
 	"slices"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
+	"unicode/utf16"
 
 	ver "github.com/Masterminds/semver/v3"
 )
@@ -27,12 +34,27 @@ const (
 	JobStateCompleted         = "Completed"
 	JobStateFailed            = "Failed"
 	JobStateRunning           = "Running"
+	JobStateDownloading       = "Downloading"
 	JobStateScheduled         = "Scheduled"
+	JobStateCompletedErrors   = "CompletedWithErrors"
+	JobStateRebootFailed      = "RebootFailed"
+	JobStateRebootCompleted   = "RebootCompleted"
 	TaskStateStarting         = "Starting"
 	TaskStateRunning          = "Running"
 	TaskStateCompleted        = "Completed"
+	TaskStateNew              = "New"
+	TaskStatePending          = "Pending"
+	TaskStateException        = "Exception"
+	TaskStateKilled           = "Killed"
+	TaskStateCancelled        = "Cancelled"
+	TaskStateInterrupted      = "Interrupted"
 	TaskStatusOK              = "OK"
 	TaskStatusCritical        = "Critical"
+	FirmwareStatusCompliant   = "Compliant"
+	FirmwareStatusOutdated    = "Outdated"
+	FirmwareStatusMismatch    = "Mismatch"
+	FirmwareStatusUnknown     = "Unknown"
+	FirmwareStepSkipped       = "Skipped"
 )
 
 type RedfishProvider interface {
@@ -40,6 +62,10 @@ type RedfishProvider interface {
 	StopServerDell() (string, error)
 	GracefulRestartDell() (string, error)
 	ResetSSLConfigDell() (string, error)
+	ResetManagerDell(resetType string) (string, error)
+	WaitForManagerReadyDell(timeout time.Duration) error
+	GetLCStatusDell() (LCStatusData, error)
+	WaitForLCReadyDell(timeout time.Duration) (LCStatusData, error)
 	GetServerPowerStateDell() (string, error)
 	CheckLoginDell() (string, bool, error)
 	ImportConfigDell(jsonData []byte) (string, error)
@@ -51,20 +77,39 @@ type RedfishProvider interface {
 	SetAttributesDell(service string, jsonData []byte) (string, error)
 	GetStorageControllers() ([]Members, error)
 	GetStorageRaidDell() ([]StorageRaidDetailsDell, error)
+	GetStorageControllerDetailsDell() ([]StorageControllerData, error)
+	SetControllerModeDell(controllerID string, mode string) (string, error)
+	SetControllerKeyDell(controllerID string, keyID string, key string) (string, error)
+	ChangePDStateDell(driveID string, state string) (string, error)
+	GetStorageOperationsDell() ([]StorageOperationData, error)
+	StartConsistencyCheckDell(volumeID string) (string, error)
+	CancelBackgroundInitDell(volumeID string) (string, error)
 	GetNetworkSwitchInfoDell() ([]SwitchData, error)
 	GetNetworkPortsDell() ([]MACData, error)
 	GetMacAddressDell() (string, error)
 	GetIdracLicenses() ([]LicenseData, error)
 	GetMacAddressModelDell() ([]MACModelDell, error)
 	GetProcessorHealthDell() ([]HealthList, error)
+	GetProcessorInventoryDell() ([]ProcessorInfo, error)
 	GetPowerHealthDell() ([]HealthList, error)
+	GetPowerMetricsDell() (PowerMetricsData, error)
+	SetPowerLimitDell(limitWatts int) (string, error)
 	GetSensorsHealthDell() ([]HealthList, error)
+	GetThermalReadingsDell() ([]ThermalReadingData, error)
+	GetMemoryDell() ([]MemoryData, error)
+	GetMemoryHealthDell() ([]HealthList, error)
+	GetPCIeDevicesDell() ([]PCIeDeviceData, error)
 	GetStorageDriveDetailsDell() ([]StorageDriveDetailsDell, error)
 	GetStorageHealthDell() ([]StorageHealthList, error)
+	GetStorageDrivesDell() ([]StorageDriveData, error)
 	GetAggHealthDataDell(model string) ([]HealthList, error)
 	GetFirmwareDell() ([]FirmwareData, error)
+	CheckFirmwareComplianceDell(baseline []FirmwareBaseline) ([]FirmwareComplianceData, error)
 	FirmwareUpdateDell() (string, error)
 	FirmwareUploadDell(repoUrl string) (string, error)
+	InstallFromRepositoryDell(repo RepositoryDell) (string, error)
+	GetRepoBasedUpdateListDell() ([]RepoUpdateDell, error)
+	PreviewRepositoryUpdateDell(repo RepositoryDell, timeout time.Duration, progress ProgressFunc) ([]RepoUpdateDell, error)
 	TaskStatusDell(taskUrl string) (ExportConfigStatus, error)
 	GetBiosDataDell() (BiosAttributesData, error)
 	GetLifecycleAttrDell() (LifeCycleData, error)
@@ -75,21 +120,88 @@ type RedfishProvider interface {
 	GetSysAttrDell() (SysAttributesData, error)
 	GetBootOrderDell() ([]BootOrderData, error)
 	SetBootOrderDell(jsonData []byte) (string, error)
+	GetBootOverrideDell() (BootOverrideData, error)
+	SetOneTimeBootDell(target string, mode string) (string, error)
+	SetBootOrderByNameDell(names []string) (string, error)
+	MoveBootDeviceFirstDell(name string) (string, error)
+	SetBootDeviceEnabledDell(name string, enabled bool) (string, error)
+	GetBootOptionsDell() ([]BootOptionData, error)
+	SetBootOptionsOrderDell(references []string) (string, error)
 	GetSystemEventLogsDell(version string) ([]SystemEventLogRes, error)
 	GetLifeCycleEventLogsDell(totalPages int) ([]LifeCycleEventLogRes, error)
 	WriteLCLog(messageDesctiption string) (string, error)
 	GetUserAccountsDell() ([]Accounts, error)
 	GetSystemInfoDell() (SystemData, error)
 	GetComponentAttr(comp string) (ExportConfigResponse, error)
+	WaitForTaskDell(taskURL string, timeout time.Duration, progress ProgressFunc) (ExportConfigStatus, error)
+	ExportSCPDell(target string, format string, includeOptions []string, timeout time.Duration, progress ProgressFunc) ([]byte, error)
+	ImportSCPDell(profile []byte, target string, shutdownType string, hostPowerState string, timeout time.Duration, progress ProgressFunc) (SCPImportResult, error)
+	PreviewImportSCPDell(profile []byte, target string, timeout time.Duration, progress ProgressFunc) (SCPImportResult, error)
 	MountImageDell(image string) (string, error)
 	UnMountImageDell() (string, error)
 	GetRemoteImageStatusDell() (ImageStatusDell, error)
+	GetVirtualMediaDell() ([]VirtualMediaData, error)
+	InsertVirtualMediaDell(request VirtualMediaRequest) (string, error)
+	EjectVirtualMediaDell(slotID string) (string, error)
 	ClearStorageControllerRaidDell(controllerID string) (string, error)
 	GetJobStatusDell(jobID string) (JobStatusDell, error)
+	WaitForJobDell(jobID string, timeout time.Duration, progress ProgressFunc) (JobStatusDell, error)
+	CreateVolumeDell(volume VolumeRequest) (string, error)
+	DeleteVolumeDell(volumeID string) (string, error)
+	AssignHotSpareDell(driveID string, volumeIDs []string) (string, error)
+	UnassignHotSpareDell(driveID string) (string, error)
+	ConvertToRAIDDell(driveIDs []string) (string, error)
+	ConvertToNonRAIDDell(driveIDs []string) (string, error)
+	BlinkDriveDell(driveID string) (string, error)
+	UnblinkDriveDell(driveID string) (string, error)
+	SecureEraseDriveDell(driveID string) (string, error)
 	ClearJobsDellForce() (string, error)
+	DeleteJobDell(jobID string) (string, error)
+	GetUpdateQueueDell() ([]JobStatusDell, error)
+	ClearUpdateQueueDell() (string, error)
+	ListRollbackVersionsDell() ([]RollbackVersionData, error)
+	RollbackFirmwareDell(firmwareID string) (string, error)
 	FleaDrainDell() (string, error)
 	PowerActionServerDell(powerAction string) (string, error)
+	WaitForPowerStateDell(state string, timeout time.Duration) error
+	GracefulShutdownWithFallbackDell(timeout time.Duration) (string, error)
 	UpdateFirmwareDell(firmwareDir string, firmwareFile string) (string, error)
+	RunFirmwarePlanDell(plan FirmwarePlanDell, progress ProgressFunc) (FirmwareUpdateReport, error)
+	UpdateFirmwareStreamDell(image io.Reader, firmwareFile string, size int64, checksum string, progress ProgressFunc) (string, error)
+	GetBootOverrideHP() (BootOverrideData, error)
+	SetOneTimeBootHP(target string, mode string) (string, error)
+	GetBootOrderHP() ([]BootOrderData, error)
+	SetBootOrderHP(order []string) (string, error)
+	GetBootOptionsHP() ([]BootOptionData, error)
+	SetBootOptionsOrderHP(references []string) (string, error)
+	ResetManagerHP(resetType string) (string, error)
+	WaitForManagerReadyHP(timeout time.Duration) error
+	PowerActionServerHP(powerAction string) (string, error)
+	WaitForPowerStateHP(state string, timeout time.Duration) error
+	GracefulShutdownWithFallbackHP(timeout time.Duration) (string, error)
+	GetPowerMetricsHP() (PowerMetricsData, error)
+	SetPowerLimitHP(limitWatts int) (string, error)
+	GetThermalReadingsHP() ([]ThermalReadingData, error)
+	GetMemoryHP() ([]MemoryData, error)
+	GetMemoryHealthHP() ([]HealthList, error)
+	GetProcessorInventoryHP() ([]ProcessorInfo, error)
+	GetStorageControllersHP() ([]StorageControllerData, error)
+	GetStorageRaidHP() ([]StorageVolumeData, error)
+	GetStorageDrivesHP() ([]StorageDriveData, error)
+	GetStorageHealthHP() ([]StorageHealthList, error)
+	GetUpdateStatusHP() (string, int, error)
+	UpdateFirmwareHP(imageURI string) (string, error)
+	UploadFirmwareHP(firmwareDir string, firmwareFile string, updateTarget bool) (string, error)
+	WaitForFirmwareUpdateHP(taskURL string, timeout time.Duration, progress ProgressFunc) (string, error)
+	GetUpdateTaskQueueHP() ([]UpdateTaskHP, error)
+	AddUpdateTaskHP(name string, filename string, updatableBy []string) (string, error)
+	GetInstallSetsHP() ([]InstallSetHP, error)
+	InvokeInstallSetHP(name string) (string, error)
+	CheckFirmwareComplianceHP(baseline []FirmwareBaseline) ([]FirmwareComplianceData, error)
+	GetVirtualMediaHP() ([]VirtualMediaData, error)
+	InsertVirtualMediaHP(request VirtualMediaRequest) (string, error)
+	EjectVirtualMediaHP(slotID string) (string, error)
+	SetVirtualMediaBootHP(slotID string, bootOnNextReset bool) (string, error)
 }
 
 // ResetType@Redfish.AllowableValues
@@ -121,6 +233,26 @@ func (c *redfishProvider) PowerActionServerDell(powerAction string) (string, err
 	return "Server " + powerAction, nil
 }
 
+// WaitForPowerStateDell ... Will wait until the server reaches the power state On or Off
+func (c *redfishProvider) WaitForPowerStateDell(state string, timeout time.Duration) error {
+	if state != "On" && state != "Off" {
+		return fmt.Errorf("invalid power state: %s", state)
+	}
+
+	return waitUntil(timeout, "power state "+state, func() (bool, error) {
+		powerState, err := c.GetServerPowerStateDell()
+		if err != nil {
+			return false, err
+		}
+		return powerState == state, nil
+	})
+}
+
+// GracefulShutdownWithFallbackDell ... Will shut down the server gracefully and force it off after the timeout
+func (c *redfishProvider) GracefulShutdownWithFallbackDell(timeout time.Duration) (string, error) {
+	return gracefulShutdownWithFallback(timeout, c.PowerActionServerDell, c.WaitForPowerStateDell, "GracefulShutdown")
+}
+
 // StartServerDell ...
 func (c *redfishProvider) StartServerDell() (string, error) {
 	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset"
@@ -162,6 +294,122 @@ func (c *redfishProvider) GracefulRestartDell() (string, error) {
 
 }
 
+// ResetManagerDell ... Will reset the iDRAC and wait for it to go down, use WaitForManagerReadyDell to wait until it is back
+// ResetType@Redfish.AllowableValues
+// "GracefulRestart"
+// "ForceRestart"
+func (c *redfishProvider) ResetManagerDell(resetType string) (string, error) {
+	if resetType != "GracefulRestart" && resetType != "ForceRestart" {
+		return "", fmt.Errorf("invalid manager reset type: %s", resetType)
+	}
+	url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Actions/Manager.Reset"
+
+	var jsonStr = []byte(`{"ResetType": "` + resetType + `"}`)
+	_, _, status, err := queryData(c, "POST", url, jsonStr)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK && status != http.StatusNoContent {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	// the iDRAC keeps answering for a few seconds after accepting the reset
+	if err := waitForManagerDown(c, "/redfish/v1", "iDRAC"); err != nil {
+		return "", err
+	}
+
+	return "Idrac " + resetType, nil
+}
+
+// WaitForManagerReadyDell ... Will wait until the service root answers, the credentials work and the Lifecycle Controller is Ready
+// wrong credentials are returned right away
+func (c *redfishProvider) WaitForManagerReadyDell(timeout time.Duration) error {
+	return waitUntil(timeout, "iDRAC to become ready", func() (bool, error) {
+		for _, url := range []string{c.Hostname + "/redfish/v1", c.Hostname + "/redfish/v1/Systems/System.Embedded.1"} {
+			_, ok, err := managerAnswers(c, url)
+			if !ok {
+				return false, err
+			}
+		}
+
+		lcStatus, err := c.GetLCStatusDell()
+		if err != nil {
+			if err.Error() == StatusUnauthorized {
+				return false, err
+			}
+			return false, nil
+		}
+
+		return lcStatus.IsReady(), nil
+	})
+}
+
+// getRemoteAPIStatusDell ... Will fetch the Lifecycle Controller remote services API status
+func (c *redfishProvider) getRemoteAPIStatusDell() (RemoteAPIStatusDell, error) {
+	urls := []string{
+		c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/DellLCService/Actions/DellLCService.GetRemoteServicesAPIStatus",
+		c.Hostname + "/redfish/v1/Dell/Managers/iDRAC.Embedded.1/DellLCService/Actions/DellLCService.GetRemoteServicesAPIStatus",
+	}
+
+	var lastStatus int
+	for _, url := range urls {
+		resp, _, status, err := queryDataForce(c, "POST", url, []byte(`{}`))
+		if err != nil {
+			return RemoteAPIStatusDell{}, err
+		}
+		if status != http.StatusOK {
+			lastStatus = status
+			continue
+		}
+
+		var x RemoteAPIStatusDell
+		json.Unmarshal(resp, &x)
+
+		return x, nil
+	}
+
+	return RemoteAPIStatusDell{}, fmt.Errorf("unable to fetch Dell remote services API status, last status: %d", lastStatus)
+}
+
+// GetLCStatusDell ... Will fetch the Lifecycle Controller status (DellLCService.GetRemoteServicesAPIStatus)
+// LCStatus: Ready, NotInitialized, ReloadingData, Disabled, InRecovery, InUse
+// ServerStatus: OutOfPOST, InPOST, PoweredOff, SystemCollectingInventory, ...
+func (c *redfishProvider) GetLCStatusDell() (LCStatusData, error) {
+	x, err := c.getRemoteAPIStatusDell()
+	if err != nil {
+		return LCStatusData{}, err
+	}
+
+	_result := LCStatusData{
+		LCStatus:     x.LCStatus,
+		RTStatus:     x.RTStatus,
+		ServerStatus: x.ServerStatus,
+		Status:       x.Status,
+	}
+
+	return _result, nil
+}
+
+// WaitForLCReadyDell ... Will wait until the Lifecycle Controller is ready to accept jobs
+func (c *redfishProvider) WaitForLCReadyDell(timeout time.Duration) (LCStatusData, error) {
+	var lcStatus LCStatusData
+	err := waitUntil(timeout, "Lifecycle Controller to become ready", func() (bool, error) {
+		var err error
+		lcStatus, err = c.GetLCStatusDell()
+		if err != nil {
+			// the iDRAC may still be restarting
+			if err.Error() == StatusUnreachable {
+				return false, nil
+			}
+			return false, err
+		}
+		return lcStatus.IsReady(), nil
+	})
+
+	return lcStatus, err
+}
+
 // ResetSSLConfigDell ... Will reset SSL configuration to factory default
 func (c *redfishProvider) ResetSSLConfigDell() (string, error) {
 	url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/DelliDRACCardService/Actions/DelliDRACCardService.SSLResetCfg"
@@ -322,6 +570,39 @@ func (c *redfishProvider) GetJobStatusDell(jobID string) (JobStatusDell, error)
 	return JobStatusDell{}, fmt.Errorf("unable to fetch job status for %s", jobID)
 }
 
+// jobFailedStatesDell ... the Dell job states and the DMTF states of /redfish/v1/JobService/Jobs which end a job as failed
+var jobFailedStatesDell = []string{JobStateFailed, JobStateCompletedErrors, JobStateRebootFailed, TaskStateException, TaskStateCancelled, TaskStateKilled, TaskStateInterrupted}
+
+// WaitForJobDell ... will wait for the job to finish, jobID can also be the job URL from a Location header
+// a Scheduled job waits for a host reboot and is polled until the timeout
+func (c *redfishProvider) WaitForJobDell(jobID string, timeout time.Duration, progress ProgressFunc) (JobStatusDell, error) {
+	jobID = path.Base(jobID)
+	deadline := time.Now().Add(timeout)
+
+	for {
+		job, err := c.GetJobStatusDell(jobID)
+		if err != nil {
+			return JobStatusDell{}, err
+		}
+
+		if progress != nil {
+			progress(job.PercentComplete, job.Message)
+		}
+
+		if job.JobState == JobStateCompleted {
+			return job, nil
+		}
+		if slices.Contains(jobFailedStatesDell, job.JobState) {
+			return job, fmt.Errorf("job %s failed: %s", jobID, job.Message)
+		}
+
+		if time.Now().After(deadline) {
+			return job, fmt.Errorf("timed out waiting for job %s, last state: %s", jobID, job.JobState)
+		}
+		time.Sleep(taskPollInterval)
+	}
+}
+
 func (c *redfishProvider) GetAllJobsDell() ([]Members, error) {
 	url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs"
 	resp, _, _, err := queryData(c, "GET", url, nil)
@@ -393,6 +674,69 @@ func (c *redfishProvider) ClearJobsDellForce() (string, error) {
 
 }
 
+// DeleteJobDell ... will delete a single job from the job queue
+func (c *redfishProvider) DeleteJobDell(jobID string) (string, error) {
+	url := c.Hostname + "/redfish/v1/Dell/Managers/iDRAC.Embedded.1/DellJobService/Actions/DellJobService.DeleteJobQueue"
+	data, _ := json.Marshal(map[string]interface{}{
+		"JobID": path.Base(jobID),
+	})
+
+	_, _, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "failure", err
+	}
+
+	if status != http.StatusOK {
+		return "failure", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return "success", nil
+}
+
+// GetUpdateQueueDell ... will list the firmware update jobs which are staged or still running
+func (c *redfishProvider) GetUpdateQueueDell() ([]JobStatusDell, error) {
+	jobs, err := c.GetJobsStatusDell()
+	if err != nil {
+		return nil, err
+	}
+
+	updateTypes := []string{"FirmwareUpdate", "RepositoryUpdate", "FirmwareRollback"}
+	doneStates := append([]string{JobStateCompleted, JobStateRebootCompleted}, jobFailedStatesDell...)
+
+	var queue []JobStatusDell
+	for _, job := range jobs {
+		if slices.Contains(updateTypes, job.JobType) && !slices.Contains(doneStates, job.JobState) {
+			queue = append(queue, job)
+		}
+	}
+
+	return queue, nil
+}
+
+// ClearUpdateQueueDell ... will delete the staged firmware update jobs, running and downloading updates can't be deleted
+// and are left in the queue, the remaining jobs are still deleted when one fails and the errors are returned together
+func (c *redfishProvider) ClearUpdateQueueDell() (string, error) {
+	queue, err := c.GetUpdateQueueDell()
+	if err != nil {
+		return "failure", err
+	}
+
+	var errs []error
+	for _, job := range queue {
+		if job.JobState == JobStateRunning || job.JobState == JobStateDownloading {
+			continue
+		}
+		if _, err := c.DeleteJobDell(job.ID); err != nil {
+			errs = append(errs, fmt.Errorf("unable to delete job %s: %w", job.ID, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return "failure", err
+	}
+	return "success", nil
+}
+
 //SetAttributesDell ... Will set the Attributes for IDRAC,Lifecycle Attributes and System
 /* Payload
 {"Attributes":{"LCAttributes.1.AutoUpdate": "1"}}
@@ -441,6 +785,236 @@ func (c *redfishProvider) ClearStorageControllerRaidDell(controllerID string) (s
 	return header.Get("Location"), nil
 }
 
+// volumeMinDrivesDell ... minimum number of drives for every RAID level
+var volumeMinDrivesDell = map[string]int{
+	"RAID0":  1,
+	"RAID1":  2,
+	"RAID5":  3,
+	"RAID6":  4,
+	"RAID10": 4,
+	"RAID50": 6,
+	"RAID60": 8,
+}
+
+// CreateVolumeDell ... Creates a virtual disk on the Storage Controller and returns the job URL
+// CapacityBytes and StripeSizeBytes are optional, the whole drives and the controller default are used when 0
+// ReadCachePolicy: Off, ReadAhead, AdaptiveReadAhead
+// WriteCachePolicy: WriteThrough, ProtectedWriteBack, UnprotectedWriteBack
+func (c *redfishProvider) CreateVolumeDell(volume VolumeRequest) (string, error) {
+	controllerURL := "/redfish/v1/Systems/System.Embedded.1/Storage/" + volume.ControllerID
+
+	resp, _, status, err := queryData(c, "GET", c.Hostname+controllerURL, nil)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("unable to fetch storage controller %s, status code: %d", volume.ControllerID, status)
+	}
+
+	var controller StorageDetailsDell
+	json.Unmarshal(resp, &controller)
+
+	if err := validateVolumeDell(controller, volume); err != nil {
+		return "", err
+	}
+
+	var drives []map[string]string
+	for _, drive := range volume.Drives {
+		drives = append(drives, map[string]string{"@odata.id": controllerURL + "/Drives/" + drive})
+	}
+
+	payload := map[string]interface{}{
+		"RAIDType": volume.RAIDType,
+		"Links": map[string]interface{}{
+			"Drives": drives,
+		},
+		"@Redfish.OperationApplyTime": "Immediate",
+	}
+	if volume.Name != "" {
+		payload["Name"] = volume.Name
+	}
+	if volume.CapacityBytes > 0 {
+		payload["CapacityBytes"] = volume.CapacityBytes
+	}
+	if volume.StripeSizeBytes > 0 {
+		payload["StripSizeBytes"] = volume.StripeSizeBytes
+	}
+	if volume.ReadCachePolicy != "" {
+		payload["ReadCachePolicy"] = volume.ReadCachePolicy
+	}
+	if volume.WriteCachePolicy != "" {
+		payload["WriteCachePolicy"] = volume.WriteCachePolicy
+	}
+	data, _ := json.Marshal(payload)
+
+	_, header, status, err := queryData(c, "POST", c.Hostname+controllerURL+"/Volumes", data)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusAccepted {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+// validateVolumeDell ... will check the requested virtual disk against the controller capabilities
+func validateVolumeDell(controller StorageDetailsDell, volume VolumeRequest) error {
+	minDrives, ok := volumeMinDrivesDell[volume.RAIDType]
+	if !ok {
+		return fmt.Errorf("invalid RAID type: %s", volume.RAIDType)
+	}
+
+	for _, storageController := range controller.StorageControllers {
+		if len(storageController.SupportedRAIDTypes) > 0 && !slices.Contains(storageController.SupportedRAIDTypes, volume.RAIDType) {
+			return fmt.Errorf("RAID type %s is not supported by %s, supported: %v", volume.RAIDType, controller.ID, storageController.SupportedRAIDTypes)
+		}
+	}
+
+	if len(volume.Drives) < minDrives {
+		return fmt.Errorf("%s requires at least %d drives, got %d", volume.RAIDType, minDrives, len(volume.Drives))
+	}
+	if (volume.RAIDType == "RAID1" && len(volume.Drives) != 2) || (volume.RAIDType == "RAID10" && len(volume.Drives)%2 != 0) {
+		return fmt.Errorf("invalid number of drives for %s: %d", volume.RAIDType, len(volume.Drives))
+	}
+
+	for _, drive := range volume.Drives {
+		found := false
+		for _, controllerDrive := range controller.Drives {
+			if strings.HasSuffix(controllerDrive.OdataId, "/"+drive) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("drive %s is not attached to %s", drive, controller.ID)
+		}
+	}
+
+	if volume.ReadCachePolicy != "" && !slices.Contains([]string{"Off", "ReadAhead", "AdaptiveReadAhead"}, volume.ReadCachePolicy) {
+		return fmt.Errorf("invalid read cache policy: %s", volume.ReadCachePolicy)
+	}
+	if volume.WriteCachePolicy != "" && !slices.Contains([]string{"WriteThrough", "ProtectedWriteBack", "UnprotectedWriteBack"}, volume.WriteCachePolicy) {
+		return fmt.Errorf("invalid write cache policy: %s", volume.WriteCachePolicy)
+	}
+	if volume.StripeSizeBytes < 0 || volume.StripeSizeBytes%(64*1024) != 0 {
+		return fmt.Errorf("invalid stripe size: %d, must be a multiple of 64KiB", volume.StripeSizeBytes)
+	}
+
+	return nil
+}
+
+// DeleteVolumeDell ... Deletes the virtual disk and returns the job URL
+// volumeID: Disk.Virtual.0:RAID.Integrated.1-1
+func (c *redfishProvider) DeleteVolumeDell(volumeID string) (string, error) {
+	_, controllerID, found := strings.Cut(volumeID, ":")
+	if !found {
+		return "", fmt.Errorf("invalid volume id: %s", volumeID)
+	}
+
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Storage/" + controllerID + "/Volumes/" + volumeID
+	_, header, status, err := queryData(c, "DELETE", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusAccepted {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+// raidServiceActionDell ... will run a DellRaidService action and return the job URL
+// realtime actions like blinking a drive don't create a job and return an empty URL
+func (c *redfishProvider) raidServiceActionDell(action string, payload map[string]interface{}) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Oem/Dell/DellRaidService/Actions/DellRaidService." + action
+
+	data, _ := json.Marshal(payload)
+
+	_, header, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusAccepted && status != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+// AssignHotSpareDell ... Assigns the drive as hot spare and returns the job URL
+// the drive becomes a global hot spare when no volumeIDs are given, otherwise a dedicated one
+func (c *redfishProvider) AssignHotSpareDell(driveID string, volumeIDs []string) (string, error) {
+	payload := map[string]interface{}{
+		"TargetFQDD": driveID,
+	}
+	if len(volumeIDs) > 0 {
+		payload["VirtualDiskArray"] = volumeIDs
+	}
+
+	return c.raidServiceActionDell("AssignSpare", payload)
+}
+
+// UnassignHotSpareDell ... Unassigns the global or dedicated hot spare and returns the job URL
+func (c *redfishProvider) UnassignHotSpareDell(driveID string) (string, error) {
+	return c.raidServiceActionDell("UnassignSpare", map[string]interface{}{
+		"TargetFQDD": driveID,
+	})
+}
+
+// ConvertToRAIDDell ... Converts the Non-RAID drives to RAID capable drives and returns the job URL
+func (c *redfishProvider) ConvertToRAIDDell(driveIDs []string) (string, error) {
+	return c.raidServiceActionDell("ConvertToRAID", map[string]interface{}{
+		"PDArray": driveIDs,
+	})
+}
+
+// ConvertToNonRAIDDell ... Converts the drives to Non-RAID (pass-through) drives and returns the job URL
+func (c *redfishProvider) ConvertToNonRAIDDell(driveIDs []string) (string, error) {
+	return c.raidServiceActionDell("ConvertToNonRAID", map[string]interface{}{
+		"PDArray": driveIDs,
+	})
+}
+
+// BlinkDriveDell ... Turns on the identify LED of the drive
+func (c *redfishProvider) BlinkDriveDell(driveID string) (string, error) {
+	return c.raidServiceActionDell("BlinkTarget", map[string]interface{}{
+		"TargetFQDD": driveID,
+	})
+}
+
+// UnblinkDriveDell ... Turns off the identify LED of the drive
+func (c *redfishProvider) UnblinkDriveDell(driveID string) (string, error) {
+	return c.raidServiceActionDell("UnBlinkTarget", map[string]interface{}{
+		"TargetFQDD": driveID,
+	})
+}
+
+// SecureEraseDriveDell ... Cryptographically erases the drive and returns the job URL
+// driveID: Disk.Bay.0:Enclosure.Internal.0-1:RAID.Integrated.1-1
+func (c *redfishProvider) SecureEraseDriveDell(driveID string) (string, error) {
+	i := strings.LastIndex(driveID, ":")
+	if i < 0 {
+		return "", fmt.Errorf("invalid drive id: %s", driveID)
+	}
+	controllerID := driveID[i+1:]
+
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Storage/" + controllerID + "/Drives/" + driveID + "/Actions/Drive.SecureErase"
+	_, header, status, err := queryData(c, "POST", url, []byte(`{}`))
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusAccepted {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return header.Get("Location"), nil
+}
+
 // FleaDrainDell ... Will Flea Drain the Server at next reboot
 func (c *redfishProvider) FleaDrainDell() (string, error) {
 	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Bios/Settings"
@@ -474,32 +1048,136 @@ func (c *redfishProvider) GetStorageControllers() ([]Members, error) {
 	return members.Members, nil
 }
 
-// GetStorageRaidDell ... Will Fetch the Storage Raid Details
-func (c *redfishProvider) GetStorageRaidDell() ([]StorageRaidDetailsDell, error) {
-
-	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Storage"
-
-	resp, _, _, err := queryData(c, "GET", url, nil)
+// GetStorageControllerDetailsDell ... Will Fetch the Storage Controller Details
+func (c *redfishProvider) GetStorageControllerDetailsDell() ([]StorageControllerData, error) {
+	controllers, err := c.GetStorageControllers()
 	if err != nil {
 		return nil, err
 	}
 
-	var (
-		x         MemberCountDell
-		_raiddata []StorageRaidDetailsDell
-	)
-
-	json.Unmarshal(resp, &x)
-	for i := range x.Members {
+	var _controllers []StorageControllerData
 
-		// check controller
-		controllerUrl := c.Hostname + x.Members[i].OdataId
-		respController, _, _, err := queryData(c, "GET", controllerUrl, nil)
+	for i := range controllers {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+controllers[i].OdataId, nil)
 		if err != nil {
 			return nil, err
 		}
-		var storageControllerDell StorageControllerDell
-		json.Unmarshal(respController, &storageControllerDell)
+
+		var y StorageDetailsDell
+		json.Unmarshal(resp, &y)
+
+		dellController := y.Oem.Dell.DellController
+		controller := StorageControllerData{
+			ID:                   y.ID,
+			Name:                 y.Name,
+			FirmwareVersion:      dellController.ControllerFirmwareVersion,
+			CacheSizeMB:          dellController.CacheSizeInMB,
+			BatteryState:         y.Oem.Dell.DellControllerBattery.PrimaryStatus,
+			Mode:                 dellController.CurrentControllerMode,
+			EncryptionCapability: dellController.EncryptionCapability,
+			EncryptionMode:       dellController.EncryptionMode,
+			SecurityStatus:       dellController.SecurityStatus,
+			Health:               y.Status.Health,
+			State:                y.Status.State,
+		}
+		if controller.Mode == "" {
+			controller.Mode = dellController.PersonalityMode
+		}
+		if len(y.StorageControllers) > 0 {
+			controller.Model = y.StorageControllers[0].Model
+			controller.SupportedRAIDTypes = y.StorageControllers[0].SupportedRAIDTypes
+			if controller.FirmwareVersion == "" {
+				controller.FirmwareVersion = y.StorageControllers[0].FirmwareVersion
+			}
+		}
+
+		_controllers = append(_controllers, controller)
+	}
+
+	return _controllers, nil
+}
+
+// SetControllerModeDell ... Changes the Storage Controller mode at next reboot and returns the job URL
+// mode: RAID, HBA or EnhancedHBA, all the volumes have to be deleted before switching to HBA
+func (c *redfishProvider) SetControllerModeDell(controllerID string, mode string) (string, error) {
+	if !slices.Contains([]string{"RAID", "HBA", "EnhancedHBA"}, mode) {
+		return "", fmt.Errorf("invalid controller mode: %s", mode)
+	}
+
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Storage/" + controllerID + "/Settings"
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"Oem": map[string]interface{}{
+			"Dell": map[string]interface{}{
+				"DellStorageController": map[string]string{
+					"ControllerMode": mode,
+				},
+			},
+		},
+		"@Redfish.SettingsApplyTime": map[string]string{
+			"ApplyTime": "OnReset",
+		},
+	})
+
+	_, header, status, err := queryData(c, "PATCH", url, data)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusAccepted {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+// SetControllerKeyDell ... Sets the local key encryption key of the Storage Controller and returns the job URL
+func (c *redfishProvider) SetControllerKeyDell(controllerID string, keyID string, key string) (string, error) {
+	return c.raidServiceActionDell("SetControllerKey", map[string]interface{}{
+		"TargetFQDD": controllerID,
+		"Keyid":      keyID,
+		"Key":        key,
+	})
+}
+
+// ChangePDStateDell ... Sets the drive Online or Offline and returns the job URL
+func (c *redfishProvider) ChangePDStateDell(driveID string, state string) (string, error) {
+	if state != "Online" && state != "Offline" {
+		return "", fmt.Errorf("invalid drive state: %s", state)
+	}
+
+	return c.raidServiceActionDell("ChangePDState", map[string]interface{}{
+		"TargetFQDD": driveID,
+		"State":      state,
+	})
+}
+
+// GetStorageRaidDell ... Will Fetch the Storage Raid Details
+func (c *redfishProvider) GetStorageRaidDell() ([]StorageRaidDetailsDell, error) {
+
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Storage"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x         MemberCountDell
+		_raiddata []StorageRaidDetailsDell
+	)
+
+	json.Unmarshal(resp, &x)
+	for i := range x.Members {
+
+		// check controller
+		controllerUrl := c.Hostname + x.Members[i].OdataId
+		respController, _, _, err := queryData(c, "GET", controllerUrl, nil)
+		if err != nil {
+			return nil, err
+		}
+		var storageControllerDell StorageControllerDell
+		json.Unmarshal(respController, &storageControllerDell)
 
 		// check volumes
 		_url := c.Hostname + x.Members[i].OdataId + "/Volumes"
@@ -543,6 +1221,79 @@ func (c *redfishProvider) GetStorageRaidDell() ([]StorageRaidDetailsDell, error)
 
 }
 
+// GetStorageOperationsDell ... Will Fetch the running rebuilds, background initializations and consistency checks of the volumes
+func (c *redfishProvider) GetStorageOperationsDell() ([]StorageOperationData, error) {
+	controllers, err := c.GetStorageControllers()
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []StorageOperationData
+
+	for i := range controllers {
+		controllerID := path.Base(controllers[i].OdataId)
+
+		resp, _, _, err := queryData(c, "GET", c.Hostname+controllers[i].OdataId+"/Volumes", nil)
+		if err != nil {
+			return nil, err
+		}
+		var y MemberCountDell
+		json.Unmarshal(resp, &y)
+
+		for k := range y.Members {
+			resp, _, _, err := queryData(c, "GET", c.Hostname+y.Members[k].OdataId, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var z StorageRaidRawDell
+			json.Unmarshal(resp, &z)
+
+			for _, operation := range z.Operations {
+				operations = append(operations, StorageOperationData{
+					VolumeID:        z.Id,
+					VolumeName:      z.Name,
+					ControllerID:    controllerID,
+					Operation:       operation.OperationName,
+					PercentComplete: operation.PercentageComplete,
+				})
+			}
+
+			// older iDRACs only report the running operation in the OEM section
+			virtualDisk := z.Oem.Dell.DellVirtualDisk
+			if len(z.Operations) == 0 && virtualDisk.OperationName != "" && virtualDisk.OperationName != "None" {
+				// the percentage is reported as a number or a string depending on the firmware
+				var operation virtualDiskOperationDell
+				json.Unmarshal(resp, &operation)
+				percent := operation.Oem.Dell.DellVirtualDisk.OperationPercentComplete
+				operations = append(operations, StorageOperationData{
+					VolumeID:        z.Id,
+					VolumeName:      z.Name,
+					ControllerID:    controllerID,
+					Operation:       virtualDisk.OperationName,
+					PercentComplete: int(percent),
+				})
+			}
+		}
+	}
+
+	return operations, nil
+}
+
+// StartConsistencyCheckDell ... Starts a consistency check on the volume and returns the job URL
+func (c *redfishProvider) StartConsistencyCheckDell(volumeID string) (string, error) {
+	return c.raidServiceActionDell("CheckVirtualDiskConsistency", map[string]interface{}{
+		"TargetFQDD": volumeID,
+	})
+}
+
+// CancelBackgroundInitDell ... Cancels the background initialization of the volume and returns the job URL
+func (c *redfishProvider) CancelBackgroundInitDell(volumeID string) (string, error) {
+	return c.raidServiceActionDell("CancelBackgroundInitialization", map[string]interface{}{
+		"TargetFQDD": volumeID,
+	})
+}
+
 // GetNetworkSwitchInfoDell ... Will fetch the Network Switch Info
 func (c *redfishProvider) GetNetworkSwitchInfoDell() ([]SwitchData, error) {
 	urls := []string{
@@ -847,6 +1598,52 @@ func (c *redfishProvider) GetMacAddressModelDell() ([]MACModelDell, error) {
 
 }
 
+// GetProcessorInventoryDell ... Will Fetch the Processor Details
+func (c *redfishProvider) GetProcessorInventoryDell() ([]ProcessorInfo, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Processors"
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x          MemberCountDell
+		processors []ProcessorInfo
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		_url := c.Hostname + x.Members[i].OdataId
+		resp, _, _, err := queryData(c, "GET", _url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y ProcessorDataDell
+
+		json.Unmarshal(resp, &y)
+
+		processor := ProcessorInfo{
+			Socket:          y.Socket,
+			Manufacturer:    y.Manufacturer,
+			Model:           strings.TrimSpace(y.Model),
+			Cores:           y.TotalCores,
+			Threads:         y.TotalThreads,
+			MaxSpeedMHz:     y.MaxSpeedMHz,
+			CurrentSpeedMHz: y.Oem.Dell.DellProcessor.CurrentClockSpeedMhz,
+			Health:          y.Status.Health,
+			State:           y.Status.State,
+		}
+		if y.ProcessorID.MicrocodeInfo != nil {
+			processor.Microcode = fmt.Sprint(y.ProcessorID.MicrocodeInfo)
+		}
+		processors = append(processors, processor)
+	}
+
+	return processors, nil
+}
+
 // GetProcessorHealthDell ... Will Fetch the Processor Health Details
 // works: R730xd,R740xd
 func (c *redfishProvider) GetProcessorHealthDell() ([]HealthList, error) {
@@ -888,7 +1685,20 @@ func (c *redfishProvider) GetProcessorHealthDell() ([]HealthList, error) {
 
 }
 
-// func (c *redfishProvider) GetMemoryHealthDell() (string, error) {}
+// GetMemoryDell ... Will Fetch the installed DIMMs with their ECC error counters
+func (c *redfishProvider) GetMemoryDell() ([]MemoryData, error) {
+	return getMemoryInventory(c, "/redfish/v1/Systems/System.Embedded.1")
+}
+
+// GetMemoryHealthDell ... Will Fetch the Memory Health Details
+func (c *redfishProvider) GetMemoryHealthDell() ([]HealthList, error) {
+	dimms, err := c.GetMemoryDell()
+	if err != nil {
+		return nil, err
+	}
+
+	return memoryHealth(dimms), nil
+}
 
 // GetPowerHealthDell ... Will Fetch the Power Health Details
 // works: R730xd,R740xd
@@ -943,6 +1753,42 @@ func (c *redfishProvider) GetPowerHealthDell() ([]HealthList, error) {
 	return powerSupplies, nil
 }
 
+// GetPowerMetricsDell ... Will Fetch the power consumption, power limit and power supply readings
+func (c *redfishProvider) GetPowerMetricsDell() (PowerMetricsData, error) {
+	return getPowerMetrics(c, "/redfish/v1/Chassis/System.Embedded.1/Power")
+}
+
+// SetPowerLimitDell ... Will cap the server power consumption to limitWatts, 0 disables power capping
+// Requires an iDRAC Enterprise or Datacenter license
+func (c *redfishProvider) SetPowerLimitDell(limitWatts int) (string, error) {
+	powerURL := "/redfish/v1/Chassis/System.Embedded.1/Power"
+	if err := checkPowerLimit(c, powerURL, limitWatts); err != nil {
+		return "", err
+	}
+
+	capSetting := "Disabled"
+	result := "Power Limit Removed"
+	if limitWatts > 0 {
+		// the limit is written before the power cap is enabled, a rejected limit leaves the capping as it was
+		var err error
+		if result, err = patchPowerLimit(c, powerURL, limitWatts); err != nil {
+			return "", err
+		}
+		capSetting = "Enabled"
+	}
+
+	jsonData, _ := json.Marshal(map[string]interface{}{
+		"Attributes": map[string]interface{}{
+			"ServerPwr.1.PowerCapSetting": capSetting,
+		},
+	})
+	if _, err := c.SetAttributesDell("system", jsonData); err != nil {
+		return "", err
+	}
+
+	return result, nil
+}
+
 // GetSensorsHealthDell ... Will Fetch the Sensors Health Details
 // works: R730xd,R740xd
 func (c *redfishProvider) GetSensorsHealthDell() ([]HealthList, error) {
@@ -999,6 +1845,16 @@ func (c *redfishProvider) GetSensorsHealthDell() ([]HealthList, error) {
 
 }
 
+// GetPCIeDevicesDell ... Will Fetch the PCIe Devices and Functions Inventory
+func (c *redfishProvider) GetPCIeDevicesDell() ([]PCIeDeviceData, error) {
+	return getPCIeDevices(c, "/redfish/v1/Systems/System.Embedded.1", "/redfish/v1/Chassis/System.Embedded.1")
+}
+
+// GetThermalReadingsDell ... Will Fetch the temperature and fan readings with their thresholds
+func (c *redfishProvider) GetThermalReadingsDell() ([]ThermalReadingData, error) {
+	return getThermalReadings(c, "/redfish/v1/Chassis/System.Embedded.1")
+}
+
 // GetStorageDriveDetailsDell ... Will Fetch the Storage Drive Details
 func (c *redfishProvider) GetStorageDriveDetailsDell() ([]StorageDriveDetailsDell, error) {
 
@@ -1051,6 +1907,44 @@ func (c *redfishProvider) GetStorageDriveDetailsDell() ([]StorageDriveDetailsDel
 
 }
 
+// GetStorageDrivesDell ... Will Fetch the Drives with their predictive failure and wear details
+func (c *redfishProvider) GetStorageDrivesDell() ([]StorageDriveData, error) {
+	controllers, err := c.GetStorageControllers()
+	if err != nil {
+		return nil, err
+	}
+
+	var drives []StorageDriveData
+
+	for i := range controllers {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+controllers[i].OdataId, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y StorageDetailsDell
+		json.Unmarshal(resp, &y)
+
+		for k := range y.Drives {
+			resp, _, _, err := queryData(c, "GET", c.Hostname+y.Drives[k].OdataId, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var z StorageDriveDetailsDell
+			json.Unmarshal(resp, &z)
+
+			drive, err := standardDriveData(c, z, y.ID)
+			if err != nil {
+				return nil, err
+			}
+			drives = append(drives, drive)
+		}
+	}
+
+	return drives, nil
+}
+
 // GetStorageHealthDell ... Will Fetch the Storage Health Details
 // works: R730xd,R740xd
 func (c *redfishProvider) GetStorageHealthDell() ([]StorageHealthList, error) {
@@ -1219,97 +2113,428 @@ func (c *redfishProvider) GetFirmwareDell() ([]FirmwareData, error) {
 
 }
 
-// FirmwareUpdateDell ... will create a job plan for firmware update
-func (c *redfishProvider) FirmwareUpdateDell() (string, error) {
-	url := c.Hostname + "/redfish/v1/UpdateService/FirmwareInventory"
-
-	resp, _, _, err := queryData(c, "GET", url, nil)
+// ListRollbackVersionsDell ... will list the components which have a previous firmware to roll back to
+func (c *redfishProvider) ListRollbackVersionsDell() ([]RollbackVersionData, error) {
+	firmware, err := c.GetFirmwareDell()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	var (
-		x         MemberCountDell
-		firmLinks []string
-	)
+	// ids look like Previous-25227-6.10.30.00__iDRAC.Embedded.1-1, the installed entry shares the component and the device
+	installed := map[string]string{}
+	for _, f := range firmware {
+		if strings.HasPrefix(f.Id, "Installed-") {
+			installed[firmwareComponentKeyDell(f.Id)] = f.Version
+		}
+	}
 
-	json.Unmarshal(resp, &x)
+	var versions []RollbackVersionData
+	for _, f := range firmware {
+		if !strings.HasPrefix(f.Id, "Previous-") {
+			continue
+		}
 
-	for i := range x.Members {
-		r, _ := regexp.Compile("Available")
-		if r.MatchString(x.Members[i].OdataId) == true {
+		key := firmwareComponentKeyDell(f.Id)
+		versions = append(versions, RollbackVersionData{
+			Name:             f.Name,
+			Id:               f.Id,
+			ComponentID:      strings.SplitN(key, "__", 2)[0],
+			InstalledVersion: installed[key],
+			RollbackVersion:  f.Version,
+			OdataId:          "/redfish/v1/UpdateService/FirmwareInventory/" + f.Id,
+		})
+	}
 
-			firmLinks = append(firmLinks, x.Members[i].OdataId)
+	return versions, nil
+}
 
-		}
+// firmwareComponentKeyDell ... will strip the state and the version from a firmware inventory id
+func firmwareComponentKeyDell(id string) string {
+	parts := strings.SplitN(id, "-", 3)
+	if len(parts) < 2 {
+		return id
 	}
 
-	data, _ := json.Marshal(map[string]interface{}{
-		"SoftwareIdentityURIs": firmLinks,
-		"InstallUpon":          "NowAndReboot",
-	})
+	key := parts[1]
+	if i := strings.Index(id, "__"); i >= 0 {
+		key += id[i:]
+	}
+	return key
+}
 
-	firmUrl := c.Hostname + "/redfish/v1/UpdateService/Actions/Oem/DellUpdateService.Install"
-	_, header, _, errr := queryData(c, "POST", firmUrl, []byte(data))
-	if errr != nil {
+// RollbackFirmwareDell ... will roll the component back to its previous firmware and return the task URL
+// firmwareID is the component id, or the id of the Installed or Previous inventory entry when several devices share the component id
+func (c *redfishProvider) RollbackFirmwareDell(firmwareID string) (string, error) {
+	versions, err := c.ListRollbackVersionsDell()
+	if err != nil {
 		return "", err
 	}
 
-	var taskURL string
-
-	for k, v := range header {
-		if k == "Location" {
-			taskURL = v[0]
-			break
+	var matches []RollbackVersionData
+	for _, v := range versions {
+		if v.Id == firmwareID || v.ComponentID == firmwareID || firmwareComponentKeyDell(v.Id) == firmwareComponentKeyDell(firmwareID) {
+			matches = append(matches, v)
 		}
 	}
-
-	if len(firmLinks) == 0 {
-		return "No Firmware Available", nil
-	} else {
-		return taskURL, nil
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no rollback version available for %s", firmwareID)
 	}
 
-}
-
-// FirmwareUploadDell ... will fetch the payload from remote repo
-func (c *redfishProvider) FirmwareUploadDell(repoUrl string) (string, error) {
+	// devices like NIC ports share the component id, only the full inventory id tells them apart
+	if len(matches) > 1 {
+		ids := make([]string, len(matches))
+		for i := range matches {
+			ids[i] = matches[i].Id
+		}
+		return "", fmt.Errorf("%s matches %d devices, use the Installed or Previous id of one of: %s", firmwareID, len(matches), strings.Join(ids, ", "))
+	}
+	rollback := matches[0]
 
 	url := c.Hostname + "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"
-
 	data, _ := json.Marshal(map[string]interface{}{
-		"ImageURI": repoUrl,
+		"ImageURI": rollback.OdataId,
 	})
 
-	_, headers, _, err := queryData(c, "POST", url, []byte(data))
+	_, header, status, err := queryData(c, "POST", url, data)
 	if err != nil {
 		return "", err
 	}
+	if status != http.StatusAccepted {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
 
-	return headers["Location"][0], nil
+	return header.Get("Location"), nil
 }
 
-func (c *redfishProvider) TaskStatusDell(taskUrl string) (ExportConfigStatus, error) {
-	url := c.Hostname + taskUrl
-
-	resp, _, _, err := queryData(c, "GET", url, nil)
+// CheckFirmwareComplianceDell ... will compare the installed firmware against the baseline for the model of the server
+func (c *redfishProvider) CheckFirmwareComplianceDell(baseline []FirmwareBaseline) ([]FirmwareComplianceData, error) {
+	system, err := c.GetSystemInfoDell()
 	if err != nil {
-		return ExportConfigStatus{}, err
+		return nil, err
 	}
 
-	var x ExportConfigStatus
-
-	json.Unmarshal(resp, &x)
+	firmware, err := c.GetFirmwareDell()
+	if err != nil {
+		return nil, err
+	}
 
-	return x, nil
+	// the inventory lists the previous and the downloaded versions next to the installed ones
+	var installed []FirmwareData
+	for _, f := range firmware {
+		if strings.HasPrefix(f.Id, "Previous") || strings.HasPrefix(f.Id, "Available") {
+			continue
+		}
+		installed = append(installed, f)
+	}
 
+	return CheckFirmwareCompliance(installed, baseline, system.Model)
 }
 
-// GetBiosDataDell ... will fetch the Bios Details
-func (c *redfishProvider) GetBiosDataDell() (BiosAttributesData, error) {
-
-	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Bios"
-
+// ParseCatalogDell ... will build a firmware baseline from a Dell Catalog.xml, keeping the latest version of every component
+// the rules match the firmware inventory ids (Installed-<componentID>-<version>), model limits the catalog to one system
+func ParseCatalogDell(catalog []byte, model string) ([]FirmwareBaseline, error) {
+	// Catalog.xml is published as UTF-16, the decoder only reads UTF-8
+	if len(catalog) >= 2 && (catalog[0] == 0xFF && catalog[1] == 0xFE || catalog[0] == 0xFE && catalog[1] == 0xFF) {
+		bigEndian := catalog[0] == 0xFE
+		units := make([]uint16, 0, len(catalog)/2)
+		for i := 2; i+1 < len(catalog); i += 2 {
+			if bigEndian {
+				units = append(units, uint16(catalog[i])<<8|uint16(catalog[i+1]))
+			} else {
+				units = append(units, uint16(catalog[i+1])<<8|uint16(catalog[i]))
+			}
+		}
+		catalog = []byte(string(utf16.Decode(units)))
+	}
+
+	var x catalogXMLDell
+	decoder := xml.NewDecoder(bytes.NewReader(catalog))
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	if err := decoder.Decode(&x); err != nil {
+		return nil, fmt.Errorf("unable to parse catalog: %w", err)
+	}
+
+	var baseline []FirmwareBaseline
+	index := map[string]int{}
+
+	for _, component := range x.SoftwareComponents {
+		var models []string
+		for _, m := range component.Models {
+			models = append(models, strings.TrimSpace(m.Name))
+		}
+		if model != "" && !firmwareModelMatches(models, model) {
+			continue
+		}
+
+		version := component.VendorVersion
+		if version == "" {
+			version = component.DellVersion
+		}
+
+		for _, device := range component.Devices {
+			if device.ComponentID == "" {
+				continue
+			}
+
+			rule := FirmwareBaseline{
+				Component:  "^Installed-" + regexp.QuoteMeta(device.ComponentID) + "-",
+				MinVersion: version,
+				Models:     models,
+			}
+
+			i, ok := index[device.ComponentID]
+			if !ok {
+				index[device.ComponentID] = len(baseline)
+				baseline = append(baseline, rule)
+				continue
+			}
+			if cmp, ok := compareFirmwareVersions(version, baseline[i].MinVersion); ok && cmp > 0 {
+				baseline[i] = rule
+			}
+		}
+	}
+
+	return baseline, nil
+}
+
+// FirmwareUpdateDell ... will create a job plan for firmware update
+func (c *redfishProvider) FirmwareUpdateDell() (string, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/FirmwareInventory"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		x         MemberCountDell
+		firmLinks []string
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		r, _ := regexp.Compile("Available")
+		if r.MatchString(x.Members[i].OdataId) == true {
+
+			firmLinks = append(firmLinks, x.Members[i].OdataId)
+
+		}
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"SoftwareIdentityURIs": firmLinks,
+		"InstallUpon":          "NowAndReboot",
+	})
+
+	firmUrl := c.Hostname + "/redfish/v1/UpdateService/Actions/Oem/DellUpdateService.Install"
+	_, header, _, errr := queryData(c, "POST", firmUrl, []byte(data))
+	if errr != nil {
+		return "", err
+	}
+
+	var taskURL string
+
+	for k, v := range header {
+		if k == "Location" {
+			taskURL = v[0]
+			break
+		}
+	}
+
+	if len(firmLinks) == 0 {
+		return "No Firmware Available", nil
+	} else {
+		return taskURL, nil
+	}
+
+}
+
+// FirmwareUploadDell ... will fetch the payload from remote repo
+func (c *redfishProvider) FirmwareUploadDell(repoUrl string) (string, error) {
+
+	url := c.Hostname + "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"ImageURI": repoUrl,
+	})
+
+	_, headers, status, err := queryData(c, "POST", url, []byte(data))
+	if err != nil {
+		return "", err
+	}
+
+	if headers.Get("Location") == "" {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return headers.Get("Location"), nil
+}
+
+// InstallFromRepositoryDell ... will compare the firmware against the catalog on the share and return the job id
+// with ApplyUpdate false nothing is installed and GetRepoBasedUpdateListDell lists the components that would change
+func (c *redfishProvider) InstallFromRepositoryDell(repo RepositoryDell) (string, error) {
+	shareType := strings.ToUpper(repo.ShareType)
+	if !slices.Contains([]string{"HTTP", "HTTPS", "NFS", "CIFS"}, shareType) {
+		return "", fmt.Errorf("invalid share type: %s", repo.ShareType)
+	}
+	if repo.IPAddress == "" {
+		return "", fmt.Errorf("missing repository address")
+	}
+
+	catalogFile := repo.CatalogFile
+	if catalogFile == "" {
+		catalogFile = "Catalog.xml"
+	}
+
+	applyUpdate := "False"
+	if repo.ApplyUpdate {
+		applyUpdate = "True"
+	}
+
+	payload := map[string]interface{}{
+		"IPAddress":    repo.IPAddress,
+		"ShareType":    shareType,
+		"ShareName":    repo.ShareName,
+		"CatalogFile":  catalogFile,
+		"ApplyUpdate":  applyUpdate,
+		"RebootNeeded": repo.RebootNeeded,
+	}
+	if repo.Username != "" {
+		payload["UserName"] = repo.Username
+		payload["Password"] = repo.Password
+	}
+	if shareType == "HTTPS" {
+		payload["IgnoreCertWarning"] = "Off"
+		if repo.IgnoreCertWarning {
+			payload["IgnoreCertWarning"] = "On"
+		}
+	}
+	data, _ := json.Marshal(payload)
+
+	url := c.Hostname + "/redfish/v1/Dell/Systems/System.Embedded.1/DellSoftwareInstallationService/Actions/DellSoftwareInstallationService.InstallFromRepository"
+	_, header, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusAccepted {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	location := header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("missing Location header for repository update job")
+	}
+
+	return path.Base(location), nil
+}
+
+// GetRepoBasedUpdateListDell ... will list the components of the last InstallFromRepository job that differ from the catalog
+// the iDRAC answers with a bad request (SUP029) when the firmware already matches the catalog, which is an empty list
+func (c *redfishProvider) GetRepoBasedUpdateListDell() ([]RepoUpdateDell, error) {
+	url := c.Hostname + "/redfish/v1/Dell/Systems/System.Embedded.1/DellSoftwareInstallationService/Actions/DellSoftwareInstallationService.GetRepoBasedUpdateList"
+	resp, _, status, err := queryData(c, "POST", url, []byte("{}"))
+	if status == http.StatusBadRequest {
+		var e ErrorResponseDell
+		json.Unmarshal(resp, &e)
+
+		// SUP029: the firmware already matches the catalog
+		var messages []string
+		for _, info := range e.Error.MessageExtendedInfo {
+			if strings.HasSuffix(info.MessageID, "SUP029") {
+				return []RepoUpdateDell{}, nil
+			}
+			messages = append(messages, info.Message)
+		}
+		if len(messages) == 0 {
+			return nil, err
+		}
+		return nil, fmt.Errorf("unable to fetch repository update list: %s", strings.Join(messages, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	var x struct {
+		PackageList string `json:"PackageList"`
+	}
+	json.Unmarshal(resp, &x)
+	if strings.TrimSpace(x.PackageList) == "" {
+		return []RepoUpdateDell{}, nil
+	}
+
+	var list repoUpdateListXMLDell
+	if err := xml.Unmarshal([]byte(x.PackageList), &list); err != nil {
+		return nil, fmt.Errorf("unable to parse package list: %w", err)
+	}
+
+	var updates []RepoUpdateDell
+	for _, instance := range list.Instances {
+		properties := map[string]string{}
+		for _, p := range instance.Properties {
+			properties[p.Name] = strings.TrimSpace(p.Value)
+		}
+
+		updates = append(updates, RepoUpdateDell{
+			Name:             properties["DisplayName"],
+			ComponentID:      properties["ComponentID"],
+			ComponentType:    properties["ComponentType"],
+			InstalledVersion: properties["ComponentInstalledVersion"],
+			PackageVersion:   properties["PackageVersion"],
+			PackageName:      properties["PackageName"],
+			PackagePath:      properties["PackagePath"],
+			Criticality:      properties["Criticality"],
+			RebootType:       properties["RebootType"],
+			JobID:            properties["JobID"],
+		})
+	}
+
+	return updates, nil
+}
+
+// PreviewRepositoryUpdateDell ... will run InstallFromRepository without applying it and return the components that would change
+func (c *redfishProvider) PreviewRepositoryUpdateDell(repo RepositoryDell, timeout time.Duration, progress ProgressFunc) ([]RepoUpdateDell, error) {
+	repo.ApplyUpdate = false
+	repo.RebootNeeded = false
+
+	jobID, err := c.InstallFromRepositoryDell(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.WaitForJobDell(jobID, timeout, progress); err != nil {
+		return nil, err
+	}
+
+	return c.GetRepoBasedUpdateListDell()
+}
+
+func (c *redfishProvider) TaskStatusDell(taskUrl string) (ExportConfigStatus, error) {
+	url := c.Hostname + taskUrl
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return ExportConfigStatus{}, err
+	}
+
+	var x ExportConfigStatus
+
+	json.Unmarshal(resp, &x)
+
+	return x, nil
+
+}
+
+// GetBiosDataDell ... will fetch the Bios Details
+func (c *redfishProvider) GetBiosDataDell() (BiosAttributesData, error) {
+
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Bios"
+
 	resp, _, _, err := queryData(c, "GET", url, nil)
 	if err != nil {
 		return BiosAttributesData{}, err
@@ -1561,8 +2786,8 @@ func (c *redfishProvider) GetSysAttrDell() (SysAttributesData, error) {
 
 }
 
-// GetBootOrderDell ... will fetch the BootOrder Details
-func (c *redfishProvider) GetBootOrderDell() ([]BootOrderData, error) {
+// getBootSourcesDell ... will fetch the raw Boot Sources falling back to the OEM path
+func (c *redfishProvider) getBootSourcesDell() (BootOrderDell, error) {
 	urls := []string{
 		c.Hostname + "/redfish/v1/Systems/System.Embedded.1/BootSources",
 		c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Oem/Dell/DellBootSources",
@@ -1572,7 +2797,7 @@ func (c *redfishProvider) GetBootOrderDell() ([]BootOrderData, error) {
 	for _, url := range urls {
 		resp, _, status, err := queryData(c, "GET", url, nil)
 		if err != nil {
-			return nil, err
+			return BootOrderDell{}, err
 		}
 		if status != http.StatusOK {
 			lastStatus = status
@@ -1581,40 +2806,50 @@ func (c *redfishProvider) GetBootOrderDell() ([]BootOrderData, error) {
 
 		var x BootOrderDell
 		if err := json.Unmarshal(resp, &x); err != nil {
-			return nil, err
+			return BootOrderDell{}, err
 		}
 
-		var bootOrder []BootOrderData
-		for i := range x.Attributes.BootSeq {
-			result := BootOrderData{
-				Enabled: x.Attributes.BootSeq[i].Enabled,
-				Index:   x.Attributes.BootSeq[i].Index,
-				Name:    x.Attributes.BootSeq[i].Name,
-				ID:      x.Attributes.BootSeq[i].ID,
-			}
+		return x, nil
+	}
 
-			bootOrder = append(bootOrder, result)
-		}
+	if lastStatus != 0 {
+		return BootOrderDell{}, fmt.Errorf("unable to fetch Dell boot sources, last status: %d", lastStatus)
+	}
 
-		for i := range x.Attributes.UefiBootSeq {
-			result := BootOrderData{
-				Enabled: x.Attributes.UefiBootSeq[i].Enabled,
-				Index:   x.Attributes.UefiBootSeq[i].Index,
-				Name:    x.Attributes.UefiBootSeq[i].Name,
-				ID:      x.Attributes.UefiBootSeq[i].ID,
-			}
+	return BootOrderDell{}, fmt.Errorf("unable to fetch Dell boot sources")
+}
+
+// GetBootOrderDell ... will fetch the BootOrder Details
+func (c *redfishProvider) GetBootOrderDell() ([]BootOrderData, error) {
+	x, err := c.getBootSourcesDell()
+	if err != nil {
+		return nil, err
+	}
 
-			bootOrder = append(bootOrder, result)
+	var bootOrder []BootOrderData
+	for i := range x.Attributes.BootSeq {
+		result := BootOrderData{
+			Enabled: x.Attributes.BootSeq[i].Enabled,
+			Index:   x.Attributes.BootSeq[i].Index,
+			Name:    x.Attributes.BootSeq[i].Name,
+			ID:      x.Attributes.BootSeq[i].ID,
 		}
 
-		return bootOrder, nil
+		bootOrder = append(bootOrder, result)
 	}
 
-	if lastStatus != 0 {
-		return nil, fmt.Errorf("unable to fetch Dell boot sources, last status: %d", lastStatus)
+	for i := range x.Attributes.UefiBootSeq {
+		result := BootOrderData{
+			Enabled: x.Attributes.UefiBootSeq[i].Enabled,
+			Index:   x.Attributes.UefiBootSeq[i].Index,
+			Name:    x.Attributes.UefiBootSeq[i].Name,
+			ID:      x.Attributes.UefiBootSeq[i].ID,
+		}
+
+		bootOrder = append(bootOrder, result)
 	}
 
-	return nil, fmt.Errorf("unable to fetch Dell boot sources")
+	return bootOrder, nil
 
 }
 
@@ -1652,57 +2887,210 @@ func (c *redfishProvider) SetBootOrderDell(jsonData []byte) (string, error) {
 
 }
 
-// GetSystemEventLogsDell ... Fetch the System Event Logs from the Idrac
-func (c *redfishProvider) GetSystemEventLogsDell(version string) ([]SystemEventLogRes, error) {
-
-	url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Logs/Sel"
-
-	resp, _, _, err := queryData(c, "GET", url, nil)
+// SetBootOrderByNameDell ... will move the named boot devices (BootOrderData Name) to the front in the given order
+// Devices which are not named keep their relative order after the named ones.
+func (c *redfishProvider) SetBootOrderByNameDell(names []string) (string, error) {
+	x, err := c.getBootSourcesDell()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	v1, verErr := ver.NewConstraint("<= 3.15.17.15")
-	if verErr != nil {
-		return nil, verErr
-	}
-	v2, verErr := ver.NewConstraint("<= 3.21.26.22")
-	if verErr != nil {
-		return nil, verErr
-	}
-	v3, verErr := ver.NewConstraint("> 3.21.26.22")
-	if verErr != nil {
-		return nil, verErr
-	}
-	v4, verErr := ver.NewVersion(version)
-	if verErr != nil {
-		return nil, verErr
+	attributes := make(map[string]interface{})
+	found := make(map[string]bool)
+	sequences := map[string][]BootSeqEntryDell{
+		"BootSeq":     x.Attributes.BootSeq,
+		"UefiBootSeq": x.Attributes.UefiBootSeq,
+	}
+	for key, seq := range sequences {
+		var first []BootSeqEntryDell
+		var rest []BootSeqEntryDell
+		for _, name := range names {
+			for _, entry := range seq {
+				if entry.Name == name {
+					first = append(first, entry)
+					found[name] = true
+				}
+			}
+		}
+		if len(first) == 0 {
+			continue
+		}
+		for _, entry := range seq {
+			if !slices.Contains(names, entry.Name) {
+				rest = append(rest, entry)
+			}
+		}
+		ordered := append(first, rest...)
+		for i := range ordered {
+			ordered[i].Index = i
+		}
+		attributes[key] = ordered
 	}
 
-	if v1.Check(v4) {
+	for _, name := range names {
+		if !found[name] {
+			return "", fmt.Errorf("boot device not found: %s", name)
+		}
+	}
 
-		var x SystemEventLogsV1Dell
+	data, _ := json.Marshal(map[string]interface{}{
+		"Attributes": attributes,
+	})
 
-		json.Unmarshal(resp, &x)
+	return c.SetBootOrderDell(data)
+}
 
-		var _systemEventLogs []SystemEventLogRes
+// MoveBootDeviceFirstDell ... will move the named boot device to the first position
+func (c *redfishProvider) MoveBootDeviceFirstDell(name string) (string, error) {
+	return c.SetBootOrderByNameDell([]string{name})
+}
 
-		for i := range x.Members {
+// SetBootDeviceEnabledDell ... will enable or disable the named boot device
+func (c *redfishProvider) SetBootDeviceEnabledDell(name string, enabled bool) (string, error) {
+	x, err := c.getBootSourcesDell()
+	if err != nil {
+		return "", err
+	}
 
-			_result := SystemEventLogRes{
-				EntryCode:  x.Members[i].EntryCode[0].Member,
-				Message:    x.Members[i].Message,
-				Name:       x.Members[i].Name,
-				SensorType: x.Members[i].SensorType[0].Member,
-				Severity:   x.Members[i].Severity,
+	attributes := make(map[string]interface{})
+	sequences := map[string][]BootSeqEntryDell{
+		"BootSeq":     x.Attributes.BootSeq,
+		"UefiBootSeq": x.Attributes.UefiBootSeq,
+	}
+	for key, seq := range sequences {
+		for i := range seq {
+			if seq[i].Name == name {
+				seq[i].Enabled = enabled
+				attributes[key] = seq
 			}
-
-			_systemEventLogs = append(_systemEventLogs, _result)
 		}
+	}
 
-		return _systemEventLogs, nil
+	if len(attributes) == 0 {
+		return "", fmt.Errorf("boot device not found: %s", name)
+	}
 
-	} else if v2.Check(v4) || v3.Check(v4) {
+	data, _ := json.Marshal(map[string]interface{}{
+		"Attributes": attributes,
+	})
+
+	return c.SetBootOrderDell(data)
+}
+
+// GetBootOptionsDell ... will fetch the standard BootOptions in Boot.BootOrder order
+// Supports iDRAC9 4.x and later Firmware
+func (c *redfishProvider) GetBootOptionsDell() ([]BootOptionData, error) {
+	return getBootOptions(c, "/redfish/v1/Systems/System.Embedded.1")
+}
+
+// SetBootOptionsOrderDell ... will set Boot.BootOrder by BootOptionReference, e.g. ["Boot0003","Boot0001"]
+// Supports iDRAC9 4.x and later Firmware, the new order is applied on the next reboot
+func (c *redfishProvider) SetBootOptionsOrderDell(references []string) (string, error) {
+	return setBootOptionsOrder(c, "/redfish/v1/Systems/System.Embedded.1", references)
+}
+
+// GetBootOverrideDell ... will fetch the current boot source override settings
+func (c *redfishProvider) GetBootOverrideDell() (BootOverrideData, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return BootOverrideData{}, err
+	}
+
+	var x SystemViewDell
+
+	json.Unmarshal(resp, &x)
+
+	_result := BootOverrideData{
+		Target:           x.Boot.BootSourceOverrideTarget,
+		Enabled:          x.Boot.BootSourceOverrideEnabled,
+		Mode:             x.Boot.BootSourceOverrideMode,
+		UefiTarget:       x.Boot.UefiTargetBootSourceOverride,
+		AllowableTargets: x.Boot.BootSourceOverrideTarget_Redfish_AllowableValues,
+	}
+
+	return _result, nil
+}
+
+// SetOneTimeBootDell ... will boot the server once from the target without changing the boot order
+// target: one of BootSourceOverrideTarget@Redfish.AllowableValues, e.g. "Pxe", "Cd", "Hdd", "BiosSetup"
+// mode: "UEFI", "Legacy" or "" to keep the current mode
+func (c *redfishProvider) SetOneTimeBootDell(target string, mode string) (string, error) {
+	override, err := c.GetBootOverrideDell()
+	if err != nil {
+		return "", err
+	}
+
+	jsonData, err := bootOverridePayload(target, mode, override.AllowableTargets)
+	if err != nil {
+		return "", err
+	}
+
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1"
+	_, _, status, err := queryData(c, "PATCH", url, jsonData)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK && status != http.StatusNoContent {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return "One Time Boot Set To " + target, nil
+}
+
+// GetSystemEventLogsDell ... Fetch the System Event Logs from the Idrac
+func (c *redfishProvider) GetSystemEventLogsDell(version string) ([]SystemEventLogRes, error) {
+
+	url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Logs/Sel"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	v1, verErr := ver.NewConstraint("<= 3.15.17.15")
+	if verErr != nil {
+		return nil, verErr
+	}
+	v2, verErr := ver.NewConstraint("<= 3.21.26.22")
+	if verErr != nil {
+		return nil, verErr
+	}
+	v3, verErr := ver.NewConstraint("> 3.21.26.22")
+	if verErr != nil {
+		return nil, verErr
+	}
+	v4, verErr := ver.NewVersion(version)
+	if verErr != nil {
+		return nil, verErr
+	}
+
+	if v1.Check(v4) {
+
+		var x SystemEventLogsV1Dell
+
+		json.Unmarshal(resp, &x)
+
+		var _systemEventLogs []SystemEventLogRes
+
+		for i := range x.Members {
+
+			_result := SystemEventLogRes{
+				EntryCode:  x.Members[i].EntryCode[0].Member,
+				Message:    x.Members[i].Message,
+				Name:       x.Members[i].Name,
+				SensorType: x.Members[i].SensorType[0].Member,
+				Severity:   x.Members[i].Severity,
+			}
+
+			_systemEventLogs = append(_systemEventLogs, _result)
+		}
+
+		return _systemEventLogs, nil
+
+	} else if v2.Check(v4) || v3.Check(v4) {
 
 		var x SystemEventLogsV2Dell
 
@@ -1857,52 +3245,306 @@ func (c *redfishProvider) GetSystemInfoDell() (SystemData, error) {
 // GetComponentAttr ... Will fetch all the component level attributes
 // Supported values are: ALL, System, BIOS, IDRAC, NIC, FC, LifecycleController, RAID.
 func (c *redfishProvider) GetComponentAttr(comp string) (ExportConfigResponse, error) {
+	resp, err := c.ExportSCPDell(comp, "JSON", nil, defaultTaskTimeout, nil)
+	if err != nil {
+		return ExportConfigResponse{}, err
+	}
+
+	var y ExportConfigResponse
+	json.Unmarshal(resp, &y)
+	return y, nil
+}
+
+// waitForTaskDell ... will poll the task until it leaves the running states and return the last response body
+func (c *redfishProvider) waitForTaskDell(taskURL string, timeout time.Duration, progress ProgressFunc) ([]byte, ExportConfigStatus, error) {
+	runningStates := []string{TaskStateNew, TaskStateStarting, TaskStateRunning, TaskStatePending}
+	failedStates := []string{TaskStateException, TaskStateKilled, TaskStateCancelled, TaskStateInterrupted}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		resp, _, status, err := queryData(c, "GET", c.Hostname+taskURL, nil)
+		if err != nil {
+			return nil, ExportConfigStatus{}, err
+		}
+		if status != http.StatusOK && status != http.StatusAccepted {
+			return nil, ExportConfigStatus{}, fmt.Errorf("unexpected status code %d for %s", status, taskURL)
+		}
+
+		var x ExportConfigStatus
+		json.Unmarshal(resp, &x)
+
+		if progress != nil && x.TaskState != "" {
+			percent := x.PercentComplete
+			if x.Oem.Dell.PercentComplete > percent {
+				percent = x.Oem.Dell.PercentComplete
+			}
+			progress(percent, taskMessageDell(x))
+		}
+
+		if status == http.StatusOK && !slices.Contains(runningStates, x.TaskState) {
+			if slices.Contains(failedStates, x.TaskState) || x.TaskStatus == TaskStatusCritical {
+				return resp, x, fmt.Errorf("task %s failed: %s", x.ID, taskMessageDell(x))
+			}
+			return resp, x, nil
+		}
+
+		if time.Now().After(deadline) {
+			return resp, x, fmt.Errorf("timed out waiting for task %s", taskURL)
+		}
+		time.Sleep(taskPollInterval)
+	}
+}
+
+// taskMessageDell ... will pick the most relevant message of a task
+func taskMessageDell(x ExportConfigStatus) string {
+	if x.Oem.Dell.Message != "" {
+		return x.Oem.Dell.Message
+	}
+	if len(x.Messages) > 0 {
+		return x.Messages[len(x.Messages)-1].Message
+	}
+	return ""
+}
+
+// WaitForTaskDell ... will wait for the task (Location header of an action) to finish
+func (c *redfishProvider) WaitForTaskDell(taskURL string, timeout time.Duration, progress ProgressFunc) (ExportConfigStatus, error) {
+	_, x, err := c.waitForTaskDell(taskURL, timeout, progress)
+	return x, err
+}
+
+// ExportSCPDell ... will export the Server Configuration Profile locally and return its content
+// target: ALL, System, BIOS, IDRAC, NIC, FC, LifecycleController, RAID or a comma separated list
+// format: JSON or XML
+// includeOptions: Default, IncludeReadOnly, IncludePasswordHashValues, IncludeCustomTelemetry
+func (c *redfishProvider) ExportSCPDell(target string, format string, includeOptions []string, timeout time.Duration, progress ProgressFunc) ([]byte, error) {
+	format = strings.ToUpper(format)
+	if format != "JSON" && format != "XML" {
+		return nil, fmt.Errorf("invalid export format: %s", format)
+	}
+
+	payload := map[string]interface{}{
+		"ExportFormat": format,
+		"ShareParameters": map[string]interface{}{
+			"Target": target,
+		},
+	}
+	if len(includeOptions) > 0 {
+		payload["IncludeInExport"] = strings.Join(includeOptions, ",")
+	}
+	data, _ := json.Marshal(payload)
 
 	url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Actions/Oem/EID_674_Manager.ExportSystemConfiguration"
+	_, header, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusAccepted {
+		return nil, fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	taskURL := header.Get("Location")
+	if taskURL == "" {
+		return nil, fmt.Errorf("missing Location header for component export task")
+	}
+
+	resp, _, err := c.waitForTaskDell(taskURL, timeout, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ImportSCPDell ... will import the Server Configuration Profile and wait for the task
+// shutdownType: Graceful, Forced or NoReboot
+// hostPowerState: On or Off, the power state after the import
+func (c *redfishProvider) ImportSCPDell(profile []byte, target string, shutdownType string, hostPowerState string, timeout time.Duration, progress ProgressFunc) (SCPImportResult, error) {
+	if !slices.Contains([]string{"Graceful", "Forced", "NoReboot"}, shutdownType) {
+		return SCPImportResult{}, fmt.Errorf("invalid shutdown type: %s", shutdownType)
+	}
+	if !slices.Contains([]string{"On", "Off"}, hostPowerState) {
+		return SCPImportResult{}, fmt.Errorf("invalid host power state: %s", hostPowerState)
+	}
+
 	data, _ := json.Marshal(map[string]interface{}{
-		"ExportFormat": "JSON",
+		"ImportBuffer": string(profile),
 		"ShareParameters": map[string]interface{}{
-			"Target": comp,
+			"Target": target,
 		},
+		"ShutdownType":   shutdownType,
+		"HostPowerState": hostPowerState,
 	})
 
-	_, header, _, err := queryData(c, "POST", url, []byte(data))
+	url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Actions/Oem/EID_674_Manager.ImportSystemConfiguration"
+	return c.importSCPDell(url, data, timeout, progress)
+}
+
+// PreviewImportSCPDell ... will validate the Server Configuration Profile without applying it
+func (c *redfishProvider) PreviewImportSCPDell(profile []byte, target string, timeout time.Duration, progress ProgressFunc) (SCPImportResult, error) {
+	data, _ := json.Marshal(map[string]interface{}{
+		"ImportBuffer": string(profile),
+		"ShareParameters": map[string]interface{}{
+			"Target": target,
+		},
+	})
+
+	url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Actions/Oem/EID_674_Manager.ImportSystemConfigurationPreview"
+	return c.importSCPDell(url, data, timeout, progress)
+}
+
+// importSCPDell ... will post the import action, wait for the task and collect the attribute results
+func (c *redfishProvider) importSCPDell(url string, data []byte, timeout time.Duration, progress ProgressFunc) (SCPImportResult, error) {
+	_, header, status, err := queryData(c, "POST", url, data)
 	if err != nil {
-		return ExportConfigResponse{}, err
+		return SCPImportResult{}, err
+	}
+	if status != http.StatusAccepted {
+		return SCPImportResult{}, fmt.Errorf("unexpected status code: %d", status)
 	}
-	var taskURL string
 
-	for k, v := range header {
-		if k == "Location" {
-			taskURL = v[0]
-			break
+	taskURL := header.Get("Location")
+	if taskURL == "" {
+		return SCPImportResult{}, fmt.Errorf("missing Location header for import task")
+	}
+
+	_, x, err := c.waitForTaskDell(taskURL, timeout, progress)
+
+	result := SCPImportResult{
+		TaskID:     x.ID,
+		TaskState:  x.TaskState,
+		TaskStatus: x.TaskStatus,
+		Message:    taskMessageDell(x),
+	}
+	for _, message := range x.Messages {
+		if message.Oem.Dell.FQDD == "" && message.Oem.Dell.Name == "" {
+			continue
 		}
+		result.Results = append(result.Results, SCPAttributeResult{
+			FQDD:      message.Oem.Dell.FQDD,
+			Name:      message.Oem.Dell.Name,
+			OldValue:  message.Oem.Dell.OldValue,
+			NewValue:  message.Oem.Dell.NewValue,
+			Status:    message.Oem.Dell.Status,
+			ErrCode:   message.Oem.Dell.ErrCode,
+			Message:   message.Message,
+			MessageID: message.MessageID,
+		})
 	}
-	if taskURL == "" {
-		return ExportConfigResponse{}, fmt.Errorf("missing Location header for component export task")
+
+	return result, err
+}
+
+// scpAttributesDell ... will index the attribute values of a Server Configuration Profile by FQDD and name
+func scpAttributesDell(profile ExportConfigResponse) map[string]map[string]string {
+	attributes := make(map[string]map[string]string)
+	for _, component := range profile.SystemConfiguration.Components {
+		if attributes[component.FQDD] == nil {
+			attributes[component.FQDD] = make(map[string]string)
+		}
+		for _, attribute := range component.Attributes {
+			attributes[component.FQDD][attribute.Name] = attribute.Value
+		}
 	}
+	return attributes
+}
 
-	for {
-		taskUrl := c.Hostname + taskURL
+// DiffSCPDell ... will compare two exported Server Configuration Profiles per FQDD and attribute
+// Change is Added or Changed for attributes of desired and Removed for attributes only present in current.
+func DiffSCPDell(current ExportConfigResponse, desired ExportConfigResponse) []SCPAttributeDiff {
+	currentAttributes := scpAttributesDell(current)
+	desiredAttributes := scpAttributesDell(desired)
+
+	var diffs []SCPAttributeDiff
+	for _, component := range desired.SystemConfiguration.Components {
+		for _, attribute := range component.Attributes {
+			oldValue, present := currentAttributes[component.FQDD][attribute.Name]
+			if !present {
+				diffs = append(diffs, SCPAttributeDiff{FQDD: component.FQDD, Name: attribute.Name, NewValue: attribute.Value, Change: "Added"})
+			} else if oldValue != attribute.Value {
+				diffs = append(diffs, SCPAttributeDiff{FQDD: component.FQDD, Name: attribute.Name, OldValue: oldValue, NewValue: attribute.Value, Change: "Changed"})
+			}
+		}
+	}
 
-		resp, _, _, err := queryData(c, "GET", taskUrl, nil)
-		if err != nil {
-			return ExportConfigResponse{}, err
+	for _, component := range current.SystemConfiguration.Components {
+		for _, attribute := range component.Attributes {
+			if _, present := desiredAttributes[component.FQDD][attribute.Name]; !present {
+				diffs = append(diffs, SCPAttributeDiff{FQDD: component.FQDD, Name: attribute.Name, OldValue: attribute.Value, Change: "Removed"})
+			}
 		}
+	}
 
-		var x ExportConfigStatus
+	return diffs
+}
 
-		json.Unmarshal(resp, &x)
+// RenderSCPTemplateDell ... will render the attribute values of a profile template with per host variables
+// Values use text/template syntax, e.g. {{.hostname}}, {{.ip}} or {{.assettag}} with vars keyed the same way.
+func RenderSCPTemplateDell(profileTemplate ExportConfigResponse, vars map[string]string) (ExportConfigResponse, error) {
+	rendered := profileTemplate
+	rendered.SystemConfiguration.Components = slices.Clone(profileTemplate.SystemConfiguration.Components)
+
+	for i := range rendered.SystemConfiguration.Components {
+		component := &rendered.SystemConfiguration.Components[i]
+		component.Attributes = slices.Clone(component.Attributes)
+		for k := range component.Attributes {
+			value := component.Attributes[k].Value
+			if !strings.Contains(value, "{{") {
+				continue
+			}
 
-		if x.TaskState == "Running" {
-			x = ExportConfigStatus{}
-			time.Sleep(time.Minute)
-		} else {
-			var y ExportConfigResponse
-			json.Unmarshal(resp, &y)
-			return y, nil
+			tmpl, err := template.New(component.FQDD).Option("missingkey=error").Parse(value)
+			if err != nil {
+				return ExportConfigResponse{}, fmt.Errorf("invalid template for %s %s: %w", component.FQDD, component.Attributes[k].Name, err)
+			}
+			var out bytes.Buffer
+			if err := tmpl.Execute(&out, vars); err != nil {
+				return ExportConfigResponse{}, fmt.Errorf("unable to render %s %s: %w", component.FQDD, component.Attributes[k].Name, err)
+			}
+			component.Attributes[k].Value = out.String()
 		}
 	}
+
+	return rendered, nil
+}
+
+// BuildSCPImportDell ... will build the ImportConfigDell payload with only the attributes of desired which differ from current
+// Read only attributes are skipped and nil is returned when nothing has to change.
+func BuildSCPImportDell(current ExportConfigResponse, desired ExportConfigResponse, target string) ([]byte, error) {
+	currentAttributes := scpAttributesDell(current)
+
+	var profile scpXMLDell
+	for _, component := range desired.SystemConfiguration.Components {
+		xmlComponent := scpXMLComponentDell{FQDD: component.FQDD}
+		for _, attribute := range component.Attributes {
+			if attribute.Set_On_Import == "False" || strings.Contains(attribute.Comment, "Read Only") {
+				continue
+			}
+			oldValue, present := currentAttributes[component.FQDD][attribute.Name]
+			if present && oldValue == attribute.Value {
+				continue
+			}
+			xmlComponent.Attributes = append(xmlComponent.Attributes, scpXMLAttributeDell{Name: attribute.Name, Value: attribute.Value})
+		}
+		if len(xmlComponent.Attributes) > 0 {
+			profile.Components = append(profile.Components, xmlComponent)
+		}
+	}
+
+	if len(profile.Components) == 0 {
+		return nil, nil
+	}
+
+	importBuffer, err := xml.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"ShareParameters": map[string]interface{}{
+			"Target": target,
+		},
+		"ImportBuffer": string(importBuffer),
+	})
 }
 
 // MountImageDell ... Will mount a image over http share
@@ -1958,52 +3600,356 @@ func (c *redfishProvider) GetRemoteImageStatusDell() (ImageStatusDell, error) {
 	return x, nil
 }
 
+// GetVirtualMediaDell ... will list all the VirtualMedia slots (CD, RemovableDisk)
+func (c *redfishProvider) GetVirtualMediaDell() ([]VirtualMediaData, error) {
+	return getVirtualMedia(c, "/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia")
+}
+
+// InsertVirtualMediaDell ... will insert the image in a VirtualMedia slot and return the slot id
+func (c *redfishProvider) InsertVirtualMediaDell(request VirtualMediaRequest) (string, error) {
+	return insertVirtualMedia(c, "/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia", request)
+}
+
+// EjectVirtualMediaDell ... will eject the image of the VirtualMedia slot, the CD slot when slotID is empty
+func (c *redfishProvider) EjectVirtualMediaDell(slotID string) (string, error) {
+	return ejectVirtualMedia(c, "/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia", slotID)
+}
+
 // UpdateFirmwareDell ... will update Dell server firmware
 func (c *redfishProvider) UpdateFirmwareDell(firmwareDir string, firmwareFile string) (string, error) {
+	fd, err := os.Open(firmwareDir + "/" + firmwareFile)
+	if err != nil {
+		return "", err
+	}
+	defer fd.Close()
+
+	info, err := fd.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	return c.UpdateFirmwareStreamDell(fd, firmwareFile, info.Size(), "", nil)
+}
+
+// UpdateFirmwareStreamDell ... will stream the firmware image to the iDRAC without buffering it in memory
+// size is used for the progress and the Content-Length and can be 0 when unknown, checksum is the hex SHA-1, SHA-256, SHA-384 or SHA-512
+// of the image and when it doesn't match the upload is aborted before the iDRAC accepts the image
+func (c *redfishProvider) UpdateFirmwareStreamDell(image io.Reader, firmwareFile string, size int64, checksum string, progress ProgressFunc) (string, error) {
+	var (
+		sum      hash.Hash
+		expected string
+		err      error
+	)
+	if checksum != "" {
+		sum, expected, err = checksumHash(checksum)
+		if err != nil {
+			return "", err
+		}
+		image = io.TeeReader(image, sum)
+	}
 
 	url := c.Hostname + "/redfish/v1/UpdateService/MultipartUpload"
-	form := new(bytes.Buffer)
-	writer := multipart.NewWriter(form)
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	// the length of the multipart envelope is measured with the same boundary so the image isn't sent chunked
+	var contentLength int64
+	if size > 0 {
+		envelope := new(bytes.Buffer)
+		envelopeWriter := multipart.NewWriter(envelope)
+		envelopeWriter.SetBoundary(writer.Boundary())
+		if _, err := writeFirmwarePartsDell(envelopeWriter, firmwareFile); err != nil {
+			return "", err
+		}
+		envelopeWriter.Close()
+		contentLength = int64(envelope.Len()) + size
+	}
 
+	go func() {
+		fw, err := writeFirmwarePartsDell(writer, firmwareFile)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		if _, err := io.Copy(fw, &progressReader{reader: image, size: size, progress: progress}); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		// the closing boundary is only written for a valid image so the iDRAC drops a corrupted upload
+		if expected != "" {
+			if actual := hex.EncodeToString(sum.Sum(nil)); actual != expected {
+				pw.CloseWithError(fmt.Errorf("checksum mismatch for %s: expected %s, got %s", firmwareFile, expected, actual))
+				return
+			}
+		}
+
+		pw.CloseWithError(writer.Close())
+	}()
+
+	_, header, status, err := postFormStream(c, url, pr, contentLength, writer.FormDataContentType(), nil)
+	pr.Close()
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusAccepted {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+// writeFirmwarePartsDell ... will write the UpdateParameters and the header of the image part, the image goes to the returned writer
+func writeFirmwarePartsDell(writer *multipart.Writer, firmwareFile string) (io.Writer, error) {
 	// added the below to create custom form field since CreateFormField doesn't set the Content-Type
 	h := make(textproto.MIMEHeader)
 	h.Set("Content-Disposition", `form-data; name="UpdateParameters"`)
 	h.Set("Content-Type", "application/json")
 	formField, err := writer.CreatePart(h)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	_, err = formField.Write([]byte(`{"@Redfish.OperationApplyTime":"Immediate"}`))
-	if err != nil {
-		return "", err
+	if _, err := formField.Write([]byte(`{"@Redfish.OperationApplyTime":"Immediate"}`)); err != nil {
+		return nil, err
 	}
 
-	fw, err := writer.CreateFormFile("UpdateFile", firmwareFile)
+	return writer.CreateFormFile("UpdateFile", firmwareFile)
+}
+
+// firmwareStepPriorityDell ... iDRAC and Lifecycle Controller first, then BIOS, then the remaining components
+func firmwareStepPriorityDell(step FirmwareUpdateStep) int {
+	name := strings.ToLower(step.Name + " " + step.Component)
+	switch {
+	case strings.Contains(name, "idrac") || strings.Contains(name, "lifecycle"):
+		return 0
+	case strings.Contains(name, "bios"):
+		return 1
+	}
+	return 2
+}
+
+// OrderFirmwareStepsDell ... will sort the steps in the iDRAC, BIOS, NIC/RAID order keeping the order within a group
+func OrderFirmwareStepsDell(steps []FirmwareUpdateStep) []FirmwareUpdateStep {
+	ordered := slices.Clone(steps)
+	slices.SortStableFunc(ordered, func(a, b FirmwareUpdateStep) int {
+		return firmwareStepPriorityDell(a) - firmwareStepPriorityDell(b)
+	})
+	return ordered
+}
+
+// installedVersionDell ... will return the installed version of the first component matching the pattern
+func (c *redfishProvider) installedVersionDell(pattern *regexp.Regexp) (string, error) {
+	if pattern == nil {
+		return "", nil
+	}
+
+	firmware, err := c.GetFirmwareDell()
 	if err != nil {
 		return "", err
 	}
-	fd, err := os.Open(firmwareDir + "/" + firmwareFile)
+
+	for _, f := range firmware {
+		if strings.HasPrefix(f.Id, "Installed") && (pattern.MatchString(f.Id) || pattern.MatchString(f.Name)) {
+			return f.Version, nil
+		}
+	}
+	return "", nil
+}
+
+// RunFirmwarePlanDell ... will run the firmware updates in order, wait on every job, wait for the iDRAC after it restarts
+// and verify the installed versions, the jobs staged until the next boot reboot the host with RebootHost and are reported
+// Scheduled without verification otherwise, the report lists every step and the remaining steps are Skipped after a failure
+func (c *redfishProvider) RunFirmwarePlanDell(plan FirmwarePlanDell, progress ProgressFunc) (FirmwareUpdateReport, error) {
+	stepTimeout := plan.StepTimeout
+	if stepTimeout == 0 {
+		stepTimeout = defaultTaskTimeout
+	}
+	managerTimeout := plan.ManagerTimeout
+	if managerTimeout == 0 {
+		managerTimeout = defaultTaskTimeout
+	}
+
+	patterns := make([]*regexp.Regexp, len(plan.Steps))
+	for i, step := range plan.Steps {
+		if step.ImageURI == "" && step.FirmwareFile == "" {
+			return FirmwareUpdateReport{}, fmt.Errorf("step %s has no firmware image", step.Name)
+		}
+		if step.ExpectedVersion != "" && step.Component == "" {
+			return FirmwareUpdateReport{}, fmt.Errorf("step %s has an expected version but no component to verify it on", step.Name)
+		}
+		if step.Component == "" {
+			continue
+		}
+		pattern, err := regexp.Compile(step.Component)
+		if err != nil {
+			return FirmwareUpdateReport{}, fmt.Errorf("invalid component pattern %s: %w", step.Component, err)
+		}
+		patterns[i] = pattern
+	}
+
+	report := FirmwareUpdateReport{Started: time.Now(), Success: true}
+	var firstErr error
+
+	for i, step := range plan.Steps {
+		result := FirmwareStepReport{
+			Name:            step.Name,
+			ExpectedVersion: step.ExpectedVersion,
+			Started:         time.Now(),
+		}
+
+		if firstErr != nil && !plan.ContinueOnError {
+			result.Status = FirmwareStepSkipped
+			result.Finished = result.Started
+			report.Steps = append(report.Steps, result)
+			continue
+		}
+
+		var stepProgress ProgressFunc
+		if progress != nil {
+			stepProgress = func(percent int, message string) {
+				progress(percent, step.Name+": "+message)
+			}
+		}
+
+		err := c.runFirmwareStepDell(step, patterns[i], plan.RebootHost, stepTimeout, managerTimeout, stepProgress, &result)
+		result.Finished = time.Now()
+		if err != nil {
+			result.Status = JobStateFailed
+			result.Error = err.Error()
+			report.Success = false
+			if firstErr == nil {
+				firstErr = fmt.Errorf("firmware step %s failed: %w", step.Name, err)
+			}
+		} else if result.JobState == JobStateScheduled {
+			result.Status = JobStateScheduled
+		} else {
+			result.Status = JobStateCompleted
+		}
+
+		report.Steps = append(report.Steps, result)
+	}
+
+	report.Finished = time.Now()
+	return report, firstErr
+}
+
+// waitForFirmwareJobDell ... will wait for the update job of a plan step, the BIOS, NIC and RAID jobs stay Scheduled
+// until the host reboots, with rebootHost the host is restarted once, or powered on when it is Off, otherwise the
+// wait ends with the job Scheduled
+func (c *redfishProvider) waitForFirmwareJobDell(result *FirmwareStepReport, managerReboot bool, rebootHost bool, stepTimeout time.Duration, managerTimeout time.Duration, progress ProgressFunc) error {
+	deadline := time.Now().Add(stepTimeout)
+	hostRebooted := false
+	var managerDown time.Time
+
+	for {
+		job, err := c.GetJobStatusDell(result.JobID)
+		if err != nil {
+			// the iDRAC answers 503, drops the connection or stops answering while it flashes itself
+			if !managerReboot {
+				return err
+			}
+			if managerDown.IsZero() {
+				managerDown = time.Now()
+			}
+			if time.Since(managerDown) > managerTimeout {
+				return fmt.Errorf("iDRAC didn't come back within %s: %w", managerTimeout, err)
+			}
+		} else {
+			managerDown = time.Time{}
+			result.JobState = job.JobState
+			if progress != nil {
+				progress(job.PercentComplete, job.Message)
+			}
+
+			if job.JobState == JobStateCompleted {
+				return nil
+			}
+			if slices.Contains(jobFailedStatesDell, job.JobState) {
+				return fmt.Errorf("job %s failed: %s", result.JobID, job.Message)
+			}
+
+			if job.JobState == JobStateScheduled && !hostRebooted {
+				if !rebootHost {
+					return nil
+				}
+				powerState, err := c.GetServerPowerStateDell()
+				if err != nil {
+					return err
+				}
+				powerAction := "GracefulRestart"
+				if powerState == "Off" {
+					powerAction = "On"
+				}
+				if _, err := c.PowerActionServerDell(powerAction); err != nil {
+					return err
+				}
+				hostRebooted = true
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for job %s, last state: %s", result.JobID, result.JobState)
+		}
+		time.Sleep(taskPollInterval)
+	}
+}
+
+// runFirmwareStepDell ... will start a single update of the plan and fill the report while it goes
+func (c *redfishProvider) runFirmwareStepDell(step FirmwareUpdateStep, pattern *regexp.Regexp, rebootHost bool, stepTimeout time.Duration, managerTimeout time.Duration, progress ProgressFunc, result *FirmwareStepReport) error {
+	var err error
+	result.VersionBefore, err = c.installedVersionDell(pattern)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	var taskURL string
+	if step.FirmwareFile != "" {
+		taskURL, err = c.UpdateFirmwareDell(step.FirmwareDir, step.FirmwareFile)
+	} else {
+		taskURL, err = c.FirmwareUploadDell(step.ImageURI)
 	}
-	defer fd.Close()
-	_, err = io.Copy(fw, fd)
 	if err != nil {
-		return "", err
+		return err
+	}
+	if taskURL == "" {
+		return fmt.Errorf("missing Location header for firmware update job")
 	}
+	result.JobID = path.Base(taskURL)
 
-	writer.Close()
+	managerReboot := step.ManagerReboot || firmwareStepPriorityDell(step) == 0
+	if err := c.waitForFirmwareJobDell(result, managerReboot, rebootHost, stepTimeout, managerTimeout, progress); err != nil {
+		return err
+	}
+	// the job is staged for the next boot and can't be verified yet
+	if result.JobState == JobStateScheduled {
+		return nil
+	}
 
-	_, header, status, err := postForm(c, url, form, writer.FormDataContentType())
+	if managerReboot {
+		// the job completes before the iDRAC restarts, give it time to go down before waiting for it to be ready
+		if err := waitForManagerDown(c, "/redfish/v1", "iDRAC"); err != nil {
+			return err
+		}
+		if err := c.WaitForManagerReadyDell(managerTimeout); err != nil {
+			return err
+		}
+	}
 
+	result.VersionAfter, err = c.installedVersionDell(pattern)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	if status != http.StatusAccepted {
-		return "", fmt.Errorf("unexpected status code: %d", status)
+	if step.ExpectedVersion != "" {
+		cmp, ok := compareFirmwareVersions(result.VersionAfter, step.ExpectedVersion)
+		result.Verified = result.VersionAfter == step.ExpectedVersion || (ok && cmp == 0)
+		if !result.Verified {
+			return fmt.Errorf("installed version %s doesn't match the expected version %s", result.VersionAfter, step.ExpectedVersion)
+		}
 	}
 
-	return header.Get("Location"), nil
+	return nil
 }
@@ -1,7 +1,9 @@
 package redfishapi
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"sync/atomic"
@@ -63,3 +65,88 @@ func TestSetBootOrderDellTreatsNoContentAsSuccess(t *testing.T) {
 		t.Fatalf("expected empty message for 204 response, got %q", message)
 	}
 }
+
+func TestMoveBootDeviceFirstDellReordersUefiBootSeq(t *testing.T) {
+	var patched BootOrderDell
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1/BootSources":
+			fmt.Fprint(w, `{"Attributes":{"UefiBootSeq":[{"Enabled":true,"Id":"a","Index":0,"Name":"Disk.Bay.0"},{"Enabled":true,"Id":"b","Index":1,"Name":"Optical.1"},{"Enabled":false,"Id":"c","Index":2,"Name":"NIC.PxeDevice.1-1"}]}}`)
+		case "/redfish/v1/Systems/System.Embedded.1/BootSources/Settings":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &patched)
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.MoveBootDeviceFirstDell("NIC.PxeDevice.1-1"); err != nil {
+		t.Fatalf("MoveBootDeviceFirstDell returned error: %v", err)
+	}
+	seq := patched.Attributes.UefiBootSeq
+	if len(seq) != 3 {
+		t.Fatalf("expected 3 UefiBootSeq entries, got %d", len(seq))
+	}
+	if seq[0].Name != "NIC.PxeDevice.1-1" || seq[0].Index != 0 || seq[1].Name != "Disk.Bay.0" || seq[2].Name != "Optical.1" || seq[2].Index != 2 {
+		t.Fatalf("unexpected UefiBootSeq order: %+v", seq)
+	}
+	if patched.Attributes.BootSeq != nil {
+		t.Fatalf("expected BootSeq to be left out of the payload, got %+v", patched.Attributes.BootSeq)
+	}
+}
+
+func TestSetBootOrderByNameDellRejectsUnknownDevice(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1/BootSources":
+			fmt.Fprint(w, `{"Attributes":{"UefiBootSeq":[{"Enabled":true,"Id":"a","Index":0,"Name":"Disk.Bay.0"}]}}`)
+		case "/redfish/v1/Systems/System.Embedded.1/BootSources/Settings":
+			t.Fatalf("boot sources should not be patched for an unknown device")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.SetBootOrderByNameDell([]string{"Disk.Bay.0", "Floppy.1"}); err == nil {
+		t.Fatalf("expected error for unknown boot device")
+	}
+}
+
+func TestSetBootOptionsOrderDellKeepsUnlistedOptions(t *testing.T) {
+	var patched SystemBootOrder
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1":
+			if r.Method == "PATCH" {
+				body, _ := io.ReadAll(r.Body)
+				json.Unmarshal(body, &patched)
+				return
+			}
+			fmt.Fprint(w, `{"Boot":{"BootOrder":["Boot0001","Boot0002","Boot0003"],"BootOptions":{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/BootOptions"}}}`)
+		case "/redfish/v1/Systems/System.Embedded.1/BootOptions":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/BootOptions/Boot0001"},{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/BootOptions/Boot0002"},{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/BootOptions/Boot0003"}]}`)
+		case "/redfish/v1/Systems/System.Embedded.1/BootOptions/Boot0001", "/redfish/v1/Systems/System.Embedded.1/BootOptions/Boot0002", "/redfish/v1/Systems/System.Embedded.1/BootOptions/Boot0003":
+			id := r.URL.Path[len(r.URL.Path)-8:]
+			fmt.Fprintf(w, `{"Id":"%s","BootOptionReference":"%s","BootOptionEnabled":true}`, id, id)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.SetBootOptionsOrderDell([]string{"Boot0003"}); err != nil {
+		t.Fatalf("SetBootOptionsOrderDell returned error: %v", err)
+	}
+	order := patched.Boot.BootOrder
+	if len(order) != 3 || order[0] != "Boot0003" || order[1] != "Boot0001" || order[2] != "Boot0002" {
+		t.Fatalf("unexpected boot order: %v", order)
+	}
+}
@@ -0,0 +1,56 @@
+package redfishapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetOneTimeBootDellPatchesBootOverride(t *testing.T) {
+	var patched map[string]map[string]string
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/Systems/System.Embedded.1" && r.Method == "GET":
+			fmt.Fprint(w, `{"Boot":{"BootSourceOverrideEnabled":"Disabled","BootSourceOverrideMode":"UEFI","BootSourceOverrideTarget":"None","BootSourceOverrideTarget@Redfish.AllowableValues":["None","Pxe","Cd","Hdd","BiosSetup"]}}`)
+		case r.URL.Path == "/redfish/v1/Systems/System.Embedded.1" && r.Method == "PATCH":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &patched)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.SetOneTimeBootDell("Pxe", "UEFI"); err != nil {
+		t.Fatalf("SetOneTimeBootDell returned error: %v", err)
+	}
+	boot := patched["Boot"]
+	if boot["BootSourceOverrideTarget"] != "Pxe" || boot["BootSourceOverrideEnabled"] != "Once" || boot["BootSourceOverrideMode"] != "UEFI" {
+		t.Fatalf("unexpected boot override payload: %+v", boot)
+	}
+}
+
+func TestSetOneTimeBootDellRejectsUnsupportedTarget(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/Systems/System.Embedded.1" && r.Method == "GET":
+			fmt.Fprint(w, `{"Boot":{"BootSourceOverrideTarget@Redfish.AllowableValues":["None","Pxe","Hdd"]}}`)
+		case r.Method == "PATCH":
+			t.Fatalf("boot override should not be patched for an unsupported target")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.SetOneTimeBootDell("Cd", ""); err == nil {
+		t.Fatalf("expected error for unsupported boot target")
+	}
+}
@@ -0,0 +1,56 @@
+package redfishapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetStorageDrivesDellReportsWearAndPredictiveFailure(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1/Storage":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1"}]}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1":
+			fmt.Fprint(w, `{"Id":"RAID.Integrated.1-1","Drives":[{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/Disk.Bay.0"},{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/Disk.Bay.1"}]}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/Disk.Bay.0":
+			fmt.Fprint(w, `{"Id":"Disk.Bay.0","Name":"Solid State Disk 0:1:0","MediaType":"SSD","NegotiatedSpeedGbs":22.5,"PredictedMediaLifeLeftPercent":87,"FailurePredicted":false,"Metrics":{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/Disk.Bay.0/Metrics"},"Oem":{"Dell":{"DellPhysicalDisk":{"PredictiveFailureState":"SmartAlertAbsent","RaidStatus":"Online"}}},"Status":{"Health":"OK","State":"Enabled"}}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/Disk.Bay.0/Metrics":
+			fmt.Fprint(w, `{"PowerOnHours":21034}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/Disk.Bay.1":
+			fmt.Fprint(w, `{"Id":"Disk.Bay.1","Name":"Physical Disk 0:1:1","MediaType":"HDD","NegotiatedSpeedGbs":12,"PredictedMediaLifeLeftPercent":null,"FailurePredicted":true,"Oem":{"Dell":{"DellPhysicalDisk":{"PredictiveFailureState":"SmartAlertPresent","RaidStatus":"Online"}}},"Status":{"Health":"Warning","State":"Enabled"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	drives, err := provider.GetStorageDrivesDell()
+	if err != nil {
+		t.Fatalf("GetStorageDrivesDell returned error: %v", err)
+	}
+	if len(drives) != 2 {
+		t.Fatalf("expected 2 drives, got %+v", drives)
+	}
+	ssd := drives[0]
+	if ssd.FailurePredicted || ssd.NegotiatedSpeedGbs != 22.5 || ssd.PredictedMediaLifeLeftPercent == nil || *ssd.PredictedMediaLifeLeftPercent != 87 || ssd.PowerOnHours == nil || *ssd.PowerOnHours != 21034 {
+		t.Fatalf("unexpected ssd: %+v", ssd)
+	}
+	hdd := drives[1]
+	if !hdd.FailurePredicted || hdd.FailureReason != "SmartAlertPresent" || hdd.PredictedMediaLifeLeftPercent != nil || hdd.PowerOnHours != nil {
+		t.Fatalf("unexpected hdd: %+v", hdd)
+	}
+}
+
+func TestStorageDriveDetailsDellKeepsIntegerNegotiatedSpeed(t *testing.T) {
+	var drive StorageDriveDetailsDell
+	if err := json.Unmarshal([]byte(`{"Id":"Disk.Bay.0","NegotiatedSpeedGbs":22.5}`), &drive); err != nil {
+		t.Fatalf("unmarshal returned error: %v", err)
+	}
+	if drive.ID != "Disk.Bay.0" || drive.NegotiatedSpeedGbs != 22 || drive.NegotiatedSpeedGbsFloat != 22.5 {
+		t.Fatalf("unexpected drive: %+v", drive)
+	}
+}
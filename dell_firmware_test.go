@@ -0,0 +1,595 @@
+package redfishapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unicode/utf16"
+)
+
+func TestCheckFirmwareComplianceComparesVersions(t *testing.T) {
+	installed := []FirmwareData{
+		{Name: "Integrated Dell Remote Access Controller", Id: "Installed-25227-7.00.00.171", Version: "7.00.00.171"},
+		{Name: "BIOS", Id: "Installed-159-2.19.1", Version: "2.19.1"},
+		{Name: "PERC H740P Mini", Id: "Installed-108255-51.16.0-4076", Version: "51.16.0-4076"},
+		{Name: "OS Drivers Pack", Id: "Installed-101734-22.10.01", Version: "22.10.01"},
+		{Name: "Lifecycle Controller", Id: "Installed-28897-A04", Version: "A04"},
+	}
+	baseline := []FirmwareBaseline{
+		{Component: "^Installed-25227-", MinVersion: "7.00.00.172"},
+		{Component: "(?i)bios", MinVersion: "2.18.0", Models: []string{"R740xd"}},
+		{Component: "PERC", ExactVersion: "51.16.0-4076"},
+		{Component: "Lifecycle", MinVersion: "3.0"},
+	}
+
+	compliance, err := CheckFirmwareCompliance(installed, baseline, "PowerEdge R740xd")
+	if err != nil {
+		t.Fatalf("CheckFirmwareCompliance returned error: %v", err)
+	}
+
+	expected := []string{FirmwareStatusOutdated, FirmwareStatusCompliant, FirmwareStatusCompliant, FirmwareStatusUnknown, FirmwareStatusUnknown}
+	for i, status := range expected {
+		if compliance[i].Status != status {
+			t.Fatalf("unexpected status for %s: %+v", installed[i].Name, compliance[i])
+		}
+	}
+
+	compliance, _ = CheckFirmwareCompliance(installed[1:2], baseline, "PowerEdge R650")
+	if compliance[0].Status != FirmwareStatusUnknown {
+		t.Fatalf("baseline for another model should not apply: %+v", compliance[0])
+	}
+
+	compliance, _ = CheckFirmwareCompliance(installed[1:2], []FirmwareBaseline{{Component: "BIOS", ExactVersion: "2.18.0"}}, "PowerEdge R740xd")
+	if compliance[0].Status != FirmwareStatusMismatch {
+		t.Fatalf("firmware newer than the exact version should be a mismatch: %+v", compliance[0])
+	}
+
+	if _, err := CheckFirmwareCompliance(installed, []FirmwareBaseline{{Component: "BIOS"}}, "PowerEdge R740xd"); err == nil {
+		t.Fatalf("expected error for a rule without a version")
+	}
+}
+
+func TestCompareFirmwareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		cmp  int
+		ok   bool
+	}{
+		{"2.19.1", "2.19.1", 0, true},
+		{"2.9.1", "2.19.1", -1, true},
+		{"7.00.00.171", "7.00.00.20", 1, true},
+		{"2.78", "2.78.0", 0, true},
+		{"1.2.0-rc1", "1.2.0", -1, true},
+		{"U30 v2.76 (02/09/2023)", "2.72", 1, true},
+		{"A04", "A05", 0, false},
+	}
+	for _, c := range cases {
+		cmp, ok := compareFirmwareVersions(c.a, c.b)
+		if cmp != c.cmp || ok != c.ok {
+			t.Fatalf("compareFirmwareVersions(%q, %q) = %d, %v", c.a, c.b, cmp, ok)
+		}
+	}
+}
+
+func TestParseCatalogDellKeepsLatestVersionForModel(t *testing.T) {
+	catalog := []byte(`<?xml version="1.0" encoding="utf-16"?>
+<Manifest baseLocation="downloads.dell.com">
+  <SoftwareComponent dellVersion="2.18.1" vendorVersion="2.18.1" path="FOLDER1/BIOS_2.18.1.EXE">
+    <Name><Display lang="en">Dell Server BIOS</Display></Name>
+    <SupportedDevices><Device componentID="159"><Display lang="en">BIOS</Display></Device></SupportedDevices>
+    <SupportedSystems><Brand key="3" prefix="PE"><Model systemID="0715"><Display lang="en">R740xd</Display></Model></Brand></SupportedSystems>
+  </SoftwareComponent>
+  <SoftwareComponent dellVersion="2.19.1" vendorVersion="2.19.1" path="FOLDER2/BIOS_2.19.1.EXE">
+    <Name><Display lang="en">Dell Server BIOS</Display></Name>
+    <SupportedDevices><Device componentID="159"><Display lang="en">BIOS</Display></Device></SupportedDevices>
+    <SupportedSystems><Brand key="3" prefix="PE"><Model systemID="0715"><Display lang="en">R740xd</Display></Model></Brand></SupportedSystems>
+  </SoftwareComponent>
+  <SoftwareComponent dellVersion="1.10.2" vendorVersion="1.10.2" path="FOLDER3/BIOS_1.10.2.EXE">
+    <Name><Display lang="en">Dell Server BIOS</Display></Name>
+    <SupportedDevices><Device componentID="160"><Display lang="en">BIOS</Display></Device></SupportedDevices>
+    <SupportedSystems><Brand key="3" prefix="PE"><Model systemID="0A3B"><Display lang="en">R650</Display></Model></Brand></SupportedSystems>
+  </SoftwareComponent>
+</Manifest>`)
+
+	baseline, err := ParseCatalogDell(catalog, "PowerEdge R740xd")
+	if err != nil {
+		t.Fatalf("ParseCatalogDell returned error: %v", err)
+	}
+	if len(baseline) != 1 || baseline[0].Component != "^Installed-159-" || baseline[0].MinVersion != "2.19.1" {
+		t.Fatalf("unexpected baseline: %+v", baseline)
+	}
+
+	// catalogs downloaded from Dell are UTF-16 with a byte order mark
+	encoded := []byte{0xFF, 0xFE}
+	for _, u := range utf16.Encode([]rune(string(catalog))) {
+		encoded = append(encoded, byte(u), byte(u>>8))
+	}
+	baseline, err = ParseCatalogDell(encoded, "R740xd")
+	if err != nil || len(baseline) != 1 || baseline[0].MinVersion != "2.19.1" {
+		t.Fatalf("unexpected baseline from UTF-16 catalog: %+v, %v", baseline, err)
+	}
+}
+
+func TestPreviewRepositoryUpdateDellListsPackages(t *testing.T) {
+	fastTaskPolling(t)
+	var payload map[string]interface{}
+
+	packageList := `<CIM CIMVERSION="2.0" DTDVERSION="2.0"><MESSAGE ID="0" PROTOCOLVERSION="1.0"><SIMPLEREQ><VALUE.NAMEDINSTANCE><INSTANCENAME CLASSNAME="DCIM_RepoUpdateSWID"><KEYBINDING NAME="InstanceID"><KEYVALUE>DCIM:INSTALLED#741__BIOS.Setup.1-1</KEYVALUE></KEYBINDING></INSTANCENAME><INSTANCE CLASSNAME="DCIM_RepoUpdateSWID"><PROPERTY NAME="DisplayName" TYPE="string"><VALUE>BIOS</VALUE></PROPERTY><PROPERTY NAME="ComponentID" TYPE="string"><VALUE>159</VALUE></PROPERTY><PROPERTY NAME="ComponentInstalledVersion" TYPE="string"><VALUE>2.18.1</VALUE></PROPERTY><PROPERTY NAME="PackageVersion" TYPE="string"><VALUE>2.19.1</VALUE></PROPERTY><PROPERTY NAME="PackagePath" TYPE="string"><VALUE>FOLDER2/BIOS_2.19.1.EXE</VALUE></PROPERTY><PROPERTY NAME="RebootType" TYPE="string"><VALUE>HOST</VALUE></PROPERTY><PROPERTY NAME="Criticality" TYPE="string"><VALUE>2</VALUE></PROPERTY></INSTANCE></VALUE.NAMEDINSTANCE></SIMPLEREQ></MESSAGE></CIM>`
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Dell/Systems/System.Embedded.1/DellSoftwareInstallationService/Actions/DellSoftwareInstallationService.InstallFromRepository":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &payload)
+			w.Header().Set("Location", "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_200")
+			w.WriteHeader(http.StatusAccepted)
+		case "/redfish/v1/JobService/Jobs/JID_200":
+			fmt.Fprint(w, `{"Id":"JID_200","JobState":"Completed","PercentComplete":100}`)
+		case "/redfish/v1/Dell/Systems/System.Embedded.1/DellSoftwareInstallationService/Actions/DellSoftwareInstallationService.GetRepoBasedUpdateList":
+			list, _ := json.Marshal(map[string]string{"PackageList": packageList})
+			w.Write(list)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	updates, err := provider.PreviewRepositoryUpdateDell(RepositoryDell{
+		IPAddress:         "downloads.dell.com",
+		ShareType:         "https",
+		ApplyUpdate:       true,
+		IgnoreCertWarning: true,
+	}, time.Minute, nil)
+	if err != nil {
+		t.Fatalf("PreviewRepositoryUpdateDell returned error: %v", err)
+	}
+	if payload["ApplyUpdate"] != "False" || payload["ShareType"] != "HTTPS" || payload["CatalogFile"] != "Catalog.xml" || payload["IgnoreCertWarning"] != "On" {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+	if len(updates) != 1 || updates[0].Name != "BIOS" || updates[0].InstalledVersion != "2.18.1" || updates[0].PackageVersion != "2.19.1" || updates[0].RebootType != "HOST" {
+		t.Fatalf("unexpected updates: %+v", updates)
+	}
+}
+
+func TestGetRepoBasedUpdateListDellEmptyOnBadRequest(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/redfish/v1/Dell/Systems/System.Embedded.1/DellSoftwareInstallationService/Actions/DellSoftwareInstallationService.GetRepoBasedUpdateList" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"@Message.ExtendedInfo":[{"MessageId":"IDRAC.2.8.SUP029","Message":"Firmware versions on server match catalog, applicable updates are not present in the repository."}]}}`)
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	updates, err := provider.GetRepoBasedUpdateListDell()
+	if err != nil {
+		t.Fatalf("GetRepoBasedUpdateListDell returned error: %v", err)
+	}
+	if updates == nil || len(updates) != 0 {
+		t.Fatalf("expected an empty update list, got %+v", updates)
+	}
+}
+
+func TestGetRepoBasedUpdateListDellReturnsOtherBadRequests(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"@Message.ExtendedInfo":[{"MessageId":"IDRAC.2.8.SUP024","Message":"Unable to complete the operation because the catalog is not available."}]}}`)
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.GetRepoBasedUpdateListDell(); err == nil || !strings.Contains(err.Error(), "catalog is not available") {
+		t.Fatalf("expected the iDRAC message as error, got %v", err)
+	}
+}
+
+func TestGetRepoBasedUpdateListDellEmptyPackageList(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"PackageList":""}`)
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	updates, err := provider.GetRepoBasedUpdateListDell()
+	if err != nil || len(updates) != 0 {
+		t.Fatalf("expected an empty update list, got %+v, %v", updates, err)
+	}
+}
+
+func TestInstallFromRepositoryDellRejectsShareType(t *testing.T) {
+	provider := &redfishProvider{Hostname: "https://127.0.0.1:1", Username: "user", Password: "pass"}
+	if _, err := provider.InstallFromRepositoryDell(RepositoryDell{IPAddress: "10.0.0.1", ShareType: "TFTP"}); err == nil {
+		t.Fatalf("expected error for unsupported share type")
+	}
+}
+
+func TestUpdateFirmwareStreamDellVerifiesChecksum(t *testing.T) {
+	image := bytes.Repeat([]byte("firmware"), 4096)
+	digest := sha256.Sum256(image)
+
+	var (
+		received      []byte
+		contentLength int64
+	)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/redfish/v1/UpdateService/MultipartUpload" {
+			http.NotFound(w, r)
+			return
+		}
+		contentLength = r.ContentLength
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		file, _, err := r.FormFile("UpdateFile")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received, _ = io.ReadAll(file)
+		w.Header().Set("Location", "/redfish/v1/TaskService/Tasks/JID_300")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+
+	var last int
+	taskURL, err := provider.UpdateFirmwareStreamDell(bytes.NewReader(image), "BIOS_2.19.1.EXE", int64(len(image)), "sha256:"+hex.EncodeToString(digest[:]), func(percent int, message string) {
+		last = percent
+	})
+	if err != nil {
+		t.Fatalf("UpdateFirmwareStreamDell returned error: %v", err)
+	}
+	if taskURL != "/redfish/v1/TaskService/Tasks/JID_300" || !bytes.Equal(received, image) || last != 100 {
+		t.Fatalf("unexpected upload, task: %q, received %d bytes, progress %d", taskURL, len(received), last)
+	}
+	if contentLength <= int64(len(image)) {
+		t.Fatalf("expected the Content-Length of the multipart body, got %d", contentLength)
+	}
+
+	received = nil
+	wrong := sha256.Sum256([]byte("other"))
+	if _, err := provider.UpdateFirmwareStreamDell(bytes.NewReader(image), "BIOS_2.19.1.EXE", 0, hex.EncodeToString(wrong[:]), nil); err == nil {
+		t.Fatalf("expected checksum mismatch error")
+	}
+	if received != nil {
+		t.Fatalf("a corrupted image should not be accepted")
+	}
+}
+
+func TestRollbackFirmwareDellUsesPreviousVersion(t *testing.T) {
+	var imageURI string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/UpdateService/FirmwareInventory":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/UpdateService/FirmwareInventory/Installed-108255-22.31.6__NIC.Integrated.1-1-1"},{"@odata.id":"/redfish/v1/UpdateService/FirmwareInventory/Previous-108255-21.80.9__NIC.Integrated.1-1-1"},{"@odata.id":"/redfish/v1/UpdateService/FirmwareInventory/Installed-159-2.19.1"}]}`)
+		case "/redfish/v1/UpdateService/FirmwareInventory/Installed-108255-22.31.6__NIC.Integrated.1-1-1":
+			fmt.Fprint(w, `{"Id":"Installed-108255-22.31.6__NIC.Integrated.1-1-1","Name":"Broadcom Gigabit Ethernet BCM57414","Version":"22.31.6","Updateable":true}`)
+		case "/redfish/v1/UpdateService/FirmwareInventory/Previous-108255-21.80.9__NIC.Integrated.1-1-1":
+			fmt.Fprint(w, `{"Id":"Previous-108255-21.80.9__NIC.Integrated.1-1-1","Name":"Broadcom Gigabit Ethernet BCM57414","Version":"21.80.9","Updateable":true}`)
+		case "/redfish/v1/UpdateService/FirmwareInventory/Installed-159-2.19.1":
+			fmt.Fprint(w, `{"Id":"Installed-159-2.19.1","Name":"BIOS","Version":"2.19.1","Updateable":true}`)
+		case "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate":
+			var payload map[string]string
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &payload)
+			imageURI = payload["ImageURI"]
+			w.Header().Set("Location", "/redfish/v1/TaskService/Tasks/JID_400")
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	versions, err := provider.ListRollbackVersionsDell()
+	if err != nil {
+		t.Fatalf("ListRollbackVersionsDell returned error: %v", err)
+	}
+	if len(versions) != 1 || versions[0].ComponentID != "108255" || versions[0].InstalledVersion != "22.31.6" || versions[0].RollbackVersion != "21.80.9" {
+		t.Fatalf("unexpected rollback versions: %+v", versions)
+	}
+
+	taskURL, err := provider.RollbackFirmwareDell("Installed-108255-22.31.6__NIC.Integrated.1-1-1")
+	if err != nil {
+		t.Fatalf("RollbackFirmwareDell returned error: %v", err)
+	}
+	if taskURL != "/redfish/v1/TaskService/Tasks/JID_400" || imageURI != "/redfish/v1/UpdateService/FirmwareInventory/Previous-108255-21.80.9__NIC.Integrated.1-1-1" {
+		t.Fatalf("unexpected rollback, task: %q, image: %q", taskURL, imageURI)
+	}
+
+	if _, err := provider.RollbackFirmwareDell("159"); err == nil {
+		t.Fatalf("expected error for a component without previous firmware")
+	}
+}
+
+func TestRollbackFirmwareDellRequiresFullIDForSharedComponent(t *testing.T) {
+	var imageURI string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/UpdateService/FirmwareInventory":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/UpdateService/FirmwareInventory/Installed-108255-22.31.6__NIC.Integrated.1-1-1"},{"@odata.id":"/redfish/v1/UpdateService/FirmwareInventory/Previous-108255-21.80.9__NIC.Integrated.1-1-1"},{"@odata.id":"/redfish/v1/UpdateService/FirmwareInventory/Installed-108255-22.31.6__NIC.Integrated.1-2-1"},{"@odata.id":"/redfish/v1/UpdateService/FirmwareInventory/Previous-108255-21.80.9__NIC.Integrated.1-2-1"}]}`)
+		case "/redfish/v1/UpdateService/FirmwareInventory/Installed-108255-22.31.6__NIC.Integrated.1-1-1", "/redfish/v1/UpdateService/FirmwareInventory/Installed-108255-22.31.6__NIC.Integrated.1-2-1":
+			fmt.Fprintf(w, `{"Id":%q,"Name":"Broadcom Gigabit Ethernet BCM57414","Version":"22.31.6","Updateable":true}`, strings.TrimPrefix(r.URL.Path, "/redfish/v1/UpdateService/FirmwareInventory/"))
+		case "/redfish/v1/UpdateService/FirmwareInventory/Previous-108255-21.80.9__NIC.Integrated.1-1-1", "/redfish/v1/UpdateService/FirmwareInventory/Previous-108255-21.80.9__NIC.Integrated.1-2-1":
+			fmt.Fprintf(w, `{"Id":%q,"Name":"Broadcom Gigabit Ethernet BCM57414","Version":"21.80.9","Updateable":true}`, strings.TrimPrefix(r.URL.Path, "/redfish/v1/UpdateService/FirmwareInventory/"))
+		case "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate":
+			var payload map[string]string
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &payload)
+			imageURI = payload["ImageURI"]
+			w.Header().Set("Location", "/redfish/v1/TaskService/Tasks/JID_401")
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.RollbackFirmwareDell("108255"); err == nil || imageURI != "" {
+		t.Fatalf("expected error for a component id shared by two ports, err: %v, image: %q", err, imageURI)
+	}
+
+	if _, err := provider.RollbackFirmwareDell("Installed-108255-22.31.6__NIC.Integrated.1-2-1"); err != nil {
+		t.Fatalf("RollbackFirmwareDell returned error: %v", err)
+	}
+	if imageURI != "/redfish/v1/UpdateService/FirmwareInventory/Previous-108255-21.80.9__NIC.Integrated.1-2-1" {
+		t.Fatalf("unexpected rollback image: %q", imageURI)
+	}
+}
+
+func TestClearUpdateQueueDellDeletesPendingUpdates(t *testing.T) {
+	var deleted []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_1"},{"@odata.id":"/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_2"},{"@odata.id":"/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_3"},{"@odata.id":"/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_4"},{"@odata.id":"/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_5"},{"@odata.id":"/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_6"}]}`)
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_1":
+			fmt.Fprint(w, `{"Id":"JID_1","JobType":"FirmwareUpdate","JobState":"Scheduled"}`)
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_2":
+			fmt.Fprint(w, `{"Id":"JID_2","JobType":"FirmwareUpdate","JobState":"Completed"}`)
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_3":
+			fmt.Fprint(w, `{"Id":"JID_3","JobType":"RAIDConfiguration","JobState":"Scheduled"}`)
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_4":
+			fmt.Fprint(w, `{"Id":"JID_4","JobType":"FirmwareUpdate","JobState":"Running"}`)
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_5":
+			fmt.Fprint(w, `{"Id":"JID_5","JobType":"RepositoryUpdate","JobState":"Downloading"}`)
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_6":
+			fmt.Fprint(w, `{"Id":"JID_6","JobType":"FirmwareUpdate","JobState":"Downloaded"}`)
+		case "/redfish/v1/Dell/Managers/iDRAC.Embedded.1/DellJobService/Actions/DellJobService.DeleteJobQueue":
+			var payload map[string]string
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &payload)
+			deleted = append(deleted, payload["JobID"])
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.ClearUpdateQueueDell(); err != nil {
+		t.Fatalf("ClearUpdateQueueDell returned error: %v", err)
+	}
+	if len(deleted) != 2 || deleted[0] != "JID_1" || deleted[1] != "JID_6" {
+		t.Fatalf("unexpected deleted jobs: %v", deleted)
+	}
+}
+
+func TestClearUpdateQueueDellContinuesAfterFailedDelete(t *testing.T) {
+	var deleted []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_1"},{"@odata.id":"/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_2"}]}`)
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_1":
+			fmt.Fprint(w, `{"Id":"JID_1","JobType":"FirmwareUpdate","JobState":"Scheduled"}`)
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_2":
+			fmt.Fprint(w, `{"Id":"JID_2","JobType":"FirmwareUpdate","JobState":"Scheduled"}`)
+		case "/redfish/v1/Dell/Managers/iDRAC.Embedded.1/DellJobService/Actions/DellJobService.DeleteJobQueue":
+			var payload map[string]string
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &payload)
+			deleted = append(deleted, payload["JobID"])
+			if payload["JobID"] == "JID_1" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	_, err := provider.ClearUpdateQueueDell()
+	if err == nil || !strings.Contains(err.Error(), "JID_1") {
+		t.Fatalf("expected the failed delete of JID_1, got %v", err)
+	}
+	if len(deleted) != 2 || deleted[1] != "JID_2" {
+		t.Fatalf("expected the remaining jobs to be deleted, got %v", deleted)
+	}
+}
+
+func TestRunFirmwarePlanDellWaitsForManagerAndVerifies(t *testing.T) {
+	fastTaskPolling(t)
+	var (
+		idracJobHits atomic.Int32
+		idracVersion atomic.Value
+		resetTypes   []string
+		images       []string
+		server       *httptest.Server
+	)
+	idracVersion.Store("6.10.30.00")
+
+	server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1", "/redfish/v1/Systems/System.Embedded.1":
+			fmt.Fprint(w, `{"PowerState":"On"}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset":
+			var payload map[string]string
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &payload)
+			resetTypes = append(resetTypes, payload["ResetType"])
+			w.WriteHeader(http.StatusNoContent)
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/DellLCService/Actions/DellLCService.GetRemoteServicesAPIStatus":
+			fmt.Fprint(w, `{"LCStatus":"Ready","Status":"Ready"}`)
+		case "/redfish/v1/UpdateService/FirmwareInventory":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/UpdateService/FirmwareInventory/Installed-25227"},{"@odata.id":"/redfish/v1/UpdateService/FirmwareInventory/Installed-159"}]}`)
+		case "/redfish/v1/UpdateService/FirmwareInventory/Installed-25227":
+			fmt.Fprintf(w, `{"Id":"Installed-25227-%s__iDRAC.Embedded.1-1","Name":"Integrated Dell Remote Access Controller","Version":"%s"}`, idracVersion.Load(), idracVersion.Load())
+		case "/redfish/v1/UpdateService/FirmwareInventory/Installed-159":
+			fmt.Fprint(w, `{"Id":"Installed-159-2.18.1__BIOS.Setup.1-1","Name":"BIOS","Version":"2.18.1"}`)
+		case "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate":
+			var payload map[string]string
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &payload)
+			images = append(images, payload["ImageURI"])
+			w.Header().Set("Location", fmt.Sprintf("/redfish/v1/TaskService/Tasks/JID_%d", len(images)))
+			w.WriteHeader(http.StatusAccepted)
+			if len(images) == 1 {
+				// the iDRAC restarts while it flashes itself and refuses connections for a moment
+				restartListener(t, server)
+			}
+		case "/redfish/v1/JobService/Jobs/JID_1":
+			// and answers 503 while it starts
+			if idracJobHits.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			idracVersion.Store("7.00.00.171")
+			fmt.Fprint(w, `{"Id":"JID_1","JobState":"Completed","PercentComplete":100}`)
+		case "/redfish/v1/JobService/Jobs/JID_2":
+			// the BIOS update is staged until the host reboots
+			if len(resetTypes) == 0 {
+				fmt.Fprint(w, `{"Id":"JID_2","JobState":"Scheduled","PercentComplete":0}`)
+				return
+			}
+			fmt.Fprint(w, `{"Id":"JID_2","JobState":"Completed","PercentComplete":100}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	server.StartTLS()
+	defer server.Close()
+
+	steps := OrderFirmwareStepsDell([]FirmwareUpdateStep{
+		{Name: "NIC", Component: "NIC", ImageURI: "http://repo/NIC.EXE"},
+		{Name: "BIOS", Component: "BIOS", ImageURI: "http://repo/BIOS.EXE", ExpectedVersion: "2.19.1"},
+		{Name: "iDRAC", Component: "iDRAC", ImageURI: "http://repo/iDRAC.EXE", ExpectedVersion: "7.00.00.171"},
+	})
+	if steps[0].Name != "iDRAC" || steps[1].Name != "BIOS" || steps[2].Name != "NIC" {
+		t.Fatalf("unexpected step order: %+v", steps)
+	}
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	report, err := provider.RunFirmwarePlanDell(FirmwarePlanDell{Steps: steps, StepTimeout: time.Minute, ManagerTimeout: time.Minute, RebootHost: true}, nil)
+	if err == nil || report.Success {
+		t.Fatalf("expected the BIOS verification to fail the plan")
+	}
+	if len(report.Steps) != 3 || len(images) != 2 {
+		t.Fatalf("unexpected report: %+v, images: %v", report, images)
+	}
+
+	idrac, bios, nic := report.Steps[0], report.Steps[1], report.Steps[2]
+	if idrac.Status != JobStateCompleted || !idrac.Verified || idrac.VersionBefore != "6.10.30.00" || idrac.VersionAfter != "7.00.00.171" || idrac.JobID != "JID_1" {
+		t.Fatalf("unexpected iDRAC step: %+v", idrac)
+	}
+	if bios.Status != JobStateFailed || bios.Verified || bios.VersionAfter != "2.18.1" || bios.Error == "" {
+		t.Fatalf("unexpected BIOS step: %+v", bios)
+	}
+	if nic.Status != FirmwareStepSkipped {
+		t.Fatalf("unexpected NIC step: %+v", nic)
+	}
+	if len(resetTypes) != 1 || resetTypes[0] != "GracefulRestart" {
+		t.Fatalf("expected a single host restart for the scheduled BIOS job, got %v", resetTypes)
+	}
+}
+
+func TestRunFirmwarePlanDellReportsScheduledWithoutHostReboot(t *testing.T) {
+	fastTaskPolling(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/UpdateService/FirmwareInventory":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/UpdateService/FirmwareInventory/Installed-159"}]}`)
+		case "/redfish/v1/UpdateService/FirmwareInventory/Installed-159":
+			fmt.Fprint(w, `{"Id":"Installed-159-2.18.1__BIOS.Setup.1-1","Name":"BIOS","Version":"2.18.1"}`)
+		case "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate":
+			w.Header().Set("Location", "/redfish/v1/TaskService/Tasks/JID_1")
+			w.WriteHeader(http.StatusAccepted)
+		case "/redfish/v1/JobService/Jobs/JID_1":
+			fmt.Fprint(w, `{"Id":"JID_1","JobState":"Scheduled","PercentComplete":0}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset":
+			t.Errorf("the host should not be rebooted without RebootHost")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	report, err := provider.RunFirmwarePlanDell(FirmwarePlanDell{Steps: []FirmwareUpdateStep{
+		{Name: "BIOS", Component: "BIOS", ImageURI: "http://repo/BIOS.EXE", ExpectedVersion: "2.19.1"},
+	}, StepTimeout: time.Minute}, nil)
+	if err != nil {
+		t.Fatalf("RunFirmwarePlanDell returned error: %v", err)
+	}
+	if len(report.Steps) != 1 || report.Steps[0].Status != JobStateScheduled || report.Steps[0].Verified {
+		t.Fatalf("expected the BIOS step to be reported Scheduled, got %+v", report.Steps)
+	}
+}
+
+// restartListener ... closes the listener of the server so new connections are refused and listens again on the same address
+func restartListener(t *testing.T, server *httptest.Server) {
+	addr := server.Listener.Addr().String()
+	server.Listener.Close()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			t.Errorf("unable to listen again on %s: %v", addr, err)
+			return
+		}
+		server.Config.Serve(tls.NewListener(listener, server.TLS))
+	}()
+}
+
+func TestRunFirmwarePlanDellRejectsExpectedVersionWithoutComponent(t *testing.T) {
+	provider := &redfishProvider{Hostname: "https://127.0.0.1:1", Username: "user", Password: "pass"}
+	_, err := provider.RunFirmwarePlanDell(FirmwarePlanDell{Steps: []FirmwareUpdateStep{
+		{Name: "BIOS", ImageURI: "http://repo/BIOS.EXE", ExpectedVersion: "2.19.1"},
+	}}, nil)
+	if err == nil {
+		t.Fatalf("expected error for a step which can't be verified")
+	}
+}
+
+func TestOrderFirmwareStepsDellIgnoresImageNames(t *testing.T) {
+	steps := OrderFirmwareStepsDell([]FirmwareUpdateStep{
+		{Name: "NIC", Component: "NIC", ImageURI: "http://repo/idrac-mirror/Network_Firmware.EXE"},
+		{Name: "BIOS", Component: "BIOS", ImageURI: "http://repo/BIOS.EXE"},
+	})
+	if steps[0].Name != "BIOS" || steps[1].Name != "NIC" {
+		t.Fatalf("unexpected step order: %+v", steps)
+	}
+}
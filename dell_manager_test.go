@@ -0,0 +1,177 @@
+package redfishapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForManagerReadyDellWaitsForLifecycleController(t *testing.T) {
+	fastTaskPolling(t)
+	var rootHits, lcHits atomic.Int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1":
+			if rootHits.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"Id":"RootService"}`)
+		case "/redfish/v1/Systems/System.Embedded.1":
+			fmt.Fprint(w, `{"Id":"System.Embedded.1"}`)
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/DellLCService/Actions/DellLCService.GetRemoteServicesAPIStatus":
+			if lcHits.Add(1) == 1 {
+				fmt.Fprint(w, `{"LCStatus":"NotInitialized","RTStatus":"Ready","ServerStatus":"OutOfPOST","Status":"NotReady"}`)
+				return
+			}
+			fmt.Fprint(w, `{"LCStatus":"Ready","RTStatus":"Ready","ServerStatus":"OutOfPOST","Status":"Ready"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if err := provider.WaitForManagerReadyDell(time.Minute); err != nil {
+		t.Fatalf("WaitForManagerReadyDell returned error: %v", err)
+	}
+	if rootHits.Load() != 3 || lcHits.Load() != 2 {
+		t.Fatalf("unexpected poll counts, service root: %d, lc status: %d", rootHits.Load(), lcHits.Load())
+	}
+}
+
+func TestResetManagerDellWaitsForResetToStart(t *testing.T) {
+	fastTaskPolling(t)
+	managerDownTimeout = time.Minute
+	var rootHits atomic.Int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Actions/Manager.Reset":
+			w.WriteHeader(http.StatusNoContent)
+		case "/redfish/v1":
+			// the iDRAC keeps answering for a while after accepting the reset
+			if hit := rootHits.Add(1); hit == 3 || hit == 4 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"Id":"RootService"}`)
+		case "/redfish/v1/Systems/System.Embedded.1":
+			fmt.Fprint(w, `{"Id":"System.Embedded.1"}`)
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/DellLCService/Actions/DellLCService.GetRemoteServicesAPIStatus":
+			fmt.Fprint(w, `{"LCStatus":"Ready","Status":"Ready"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.ResetManagerDell("GracefulRestart"); err != nil {
+		t.Fatalf("ResetManagerDell returned error: %v", err)
+	}
+	if rootHits.Load() != 3 {
+		t.Fatalf("expected the reset to wait for the iDRAC to go down, service root hits: %d", rootHits.Load())
+	}
+	if err := provider.WaitForManagerReadyDell(time.Minute); err != nil {
+		t.Fatalf("WaitForManagerReadyDell returned error: %v", err)
+	}
+	if rootHits.Load() != 5 {
+		t.Fatalf("expected the iDRAC to be seen down and back, service root hits: %d", rootHits.Load())
+	}
+}
+
+func TestWaitForManagerReadyDellReturnsAtOnceWhenReady(t *testing.T) {
+	fastTaskPolling(t)
+	managerDownTimeout = time.Minute
+	var rootHits atomic.Int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1":
+			rootHits.Add(1)
+			fmt.Fprint(w, `{"Id":"RootService"}`)
+		case "/redfish/v1/Systems/System.Embedded.1":
+			fmt.Fprint(w, `{"Id":"System.Embedded.1"}`)
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/DellLCService/Actions/DellLCService.GetRemoteServicesAPIStatus":
+			fmt.Fprint(w, `{"LCStatus":"Ready","Status":"Ready"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if err := provider.WaitForManagerReadyDell(time.Minute); err != nil {
+		t.Fatalf("WaitForManagerReadyDell returned error: %v", err)
+	}
+	if rootHits.Load() != 1 {
+		t.Fatalf("expected a ready iDRAC to be reported at once, service root hits: %d", rootHits.Load())
+	}
+}
+
+func TestWaitForManagerReadyDellFailsOnWrongCredentials(t *testing.T) {
+	fastTaskPolling(t)
+	var rootHits atomic.Int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rootHits.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "wrong"}
+	err := provider.WaitForManagerReadyDell(time.Minute)
+	if err == nil || err.Error() != StatusUnauthorized || rootHits.Load() != 1 {
+		t.Fatalf("expected an immediate %s error, got %v after %d hits", StatusUnauthorized, err, rootHits.Load())
+	}
+}
+
+func TestWaitForManagerReadyDellTimesOut(t *testing.T) {
+	fastTaskPolling(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if err := provider.WaitForManagerReadyDell(10 * time.Millisecond); err == nil {
+		t.Fatalf("expected timeout error")
+	}
+}
+
+func TestWaitForLCReadyDellFallsBackToLegacyPath(t *testing.T) {
+	fastTaskPolling(t)
+	var legacyHits atomic.Int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Dell/Managers/iDRAC.Embedded.1/DellLCService/Actions/DellLCService.GetRemoteServicesAPIStatus":
+			if legacyHits.Add(1) == 1 {
+				fmt.Fprint(w, `{"LCStatus":"Ready","RTStatus":"Ready","ServerStatus":"InPOST","Status":"NotReady"}`)
+				return
+			}
+			fmt.Fprint(w, `{"LCStatus":"Ready","RTStatus":"Ready","ServerStatus":"OutOfPOST","Status":"Ready"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	lcStatus, err := provider.WaitForLCReadyDell(time.Minute)
+	if err != nil {
+		t.Fatalf("WaitForLCReadyDell returned error: %v", err)
+	}
+	if legacyHits.Load() != 2 {
+		t.Fatalf("expected legacy path to be polled twice, got %d", legacyHits.Load())
+	}
+	if lcStatus.ServerStatus != "OutOfPOST" || lcStatus.Status != "Ready" {
+		t.Fatalf("unexpected lc status: %+v", lcStatus)
+	}
+}
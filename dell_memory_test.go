@@ -0,0 +1,75 @@
+package redfishapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetMemoryDellReadsDIMMsAndECCCounters(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1/Memory":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Memory/DIMM.Socket.A1"},{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Memory/DIMM.Socket.A2"}]}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Memory/DIMM.Socket.A1":
+			fmt.Fprint(w, `{"Id":"DIMM.Socket.A1","Name":"DIMM A1","DeviceLocator":"DIMM A1","CapacityMiB":32768,"OperatingSpeedMhz":3200,"Manufacturer":"Hynix Semiconductor","PartNumber":"HMA84GR7DJR4N-XN    ","SerialNumber":"3548A1B2","MemoryDeviceType":"DDR4","ErrorCorrection":"MultiBitECC","Metrics":{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Memory/DIMM.Socket.A1/MemoryMetrics"},"Status":{"Health":"OK","State":"Enabled"}}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Memory/DIMM.Socket.A1/MemoryMetrics":
+			fmt.Fprint(w, `{"CurrentPeriod":{"CorrectableECCErrorCount":3},"LifeTime":{"UncorrectableECCErrorCount":0}}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Memory/DIMM.Socket.A2":
+			fmt.Fprint(w, `{"Id":"DIMM.Socket.A2","Name":"DIMM A2","DeviceLocator":"DIMM A2","Status":{"State":"Absent"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	dimms, err := provider.GetMemoryDell()
+	if err != nil {
+		t.Fatalf("GetMemoryDell returned error: %v", err)
+	}
+	if len(dimms) != 1 {
+		t.Fatalf("expected absent slots to be skipped, got %+v", dimms)
+	}
+	dimm := dimms[0]
+	if dimm.Slot != "DIMM A1" || dimm.CapacityMiB != 32768 || dimm.SpeedMHz != 3200 || dimm.PartNumber != "HMA84GR7DJR4N-XN" || dimm.MemoryType != "DDR4" {
+		t.Fatalf("unexpected dimm: %+v", dimm)
+	}
+	if dimm.CorrectableErrors == nil || *dimm.CorrectableErrors != 3 || dimm.UncorrectableErrors == nil || *dimm.UncorrectableErrors != 0 {
+		t.Fatalf("unexpected ecc counters: %+v", dimm)
+	}
+}
+
+func TestGetMemoryHealthHPReadsILO4DIMMs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/1/Memory":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/1/Memory/proc1dimm1/"},{"@odata.id":"/redfish/v1/Systems/1/Memory/proc1dimm2/"}]}`)
+		case "/redfish/v1/Systems/1/Memory/proc1dimm1/":
+			fmt.Fprint(w, `{"Name":"proc1dimm1","SocketLocator":"PROC  1 DIMM  1 ","SizeMB":16384,"MaximumFrequencyMHz":2400,"DIMMType":"DDR4","DIMMStatus":"GoodInUse"}`)
+		case "/redfish/v1/Systems/1/Memory/proc1dimm2/":
+			fmt.Fprint(w, `{"Name":"proc1dimm2","SocketLocator":"PROC  1 DIMM  2 ","DIMMStatus":"NotPresent"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	dimms, err := provider.GetMemoryHP()
+	if err != nil {
+		t.Fatalf("GetMemoryHP returned error: %v", err)
+	}
+	if len(dimms) != 1 || dimms[0].CapacityMiB != 16384 || dimms[0].SpeedMHz != 2400 || dimms[0].MemoryType != "DDR4" || dimms[0].State != "GoodInUse" {
+		t.Fatalf("unexpected dimms: %+v", dimms)
+	}
+
+	health, err := provider.GetMemoryHealthHP()
+	if err != nil {
+		t.Fatalf("GetMemoryHealthHP returned error: %v", err)
+	}
+	if len(health) != 1 || health[0].Name != "PROC  1 DIMM  1" {
+		t.Fatalf("unexpected memory health: %+v", health)
+	}
+}
@@ -0,0 +1,44 @@
+package redfishapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetPCIeDevicesDellReadsDevicesAndFunctions(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1":
+			fmt.Fprint(w, `{"Id":"System.Embedded.1","PCIeDevices":[]}`)
+		case "/redfish/v1/Chassis/System.Embedded.1/PCIeDevices":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Chassis/System.Embedded.1/PCIeDevices/59-0"}]}`)
+		case "/redfish/v1/Chassis/System.Embedded.1/PCIeDevices/59-0":
+			fmt.Fprint(w, `{"Id":"59-0","Name":"BCM57414 NetXtreme-E 10Gb/25Gb RDMA Ethernet Controller","Manufacturer":"Broadcom Inc. and subsidiaries","FirmwareVersion":"22.31.6","DeviceType":"MultiFunction","PCIeInterface":{"LanesInUse":8,"MaxLanes":8,"PCIeType":"Gen3","MaxPCIeType":"Gen3"},"Slot":{"Location":{"PartLocation":{"LocationOrdinalValue":3}}},"PCIeFunctions":{"@odata.id":"/redfish/v1/Chassis/System.Embedded.1/PCIeDevices/59-0/PCIeFunctions"},"Status":{"Health":"OK","State":"Enabled"}}`)
+		case "/redfish/v1/Chassis/System.Embedded.1/PCIeDevices/59-0/PCIeFunctions":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Chassis/System.Embedded.1/PCIeDevices/59-0/PCIeFunctions/59-0-0"}]}`)
+		case "/redfish/v1/Chassis/System.Embedded.1/PCIeDevices/59-0/PCIeFunctions/59-0-0":
+			fmt.Fprint(w, `{"Id":"59-0-0","FunctionId":0,"FunctionType":"Physical","DeviceClass":"NetworkController","ClassCode":"0x020000","VendorId":"0x14e4","DeviceId":"0x16d7","SubsystemVendorId":"0x14e4","SubsystemId":"0x4140","Status":{"Health":"OK","State":"Enabled"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	devices, err := provider.GetPCIeDevicesDell()
+	if err != nil {
+		t.Fatalf("GetPCIeDevicesDell returned error: %v", err)
+	}
+	if len(devices) != 1 {
+		t.Fatalf("expected 1 device, got %+v", devices)
+	}
+	device := devices[0]
+	if device.Slot != "3" || device.LanesInUse != 8 || device.PCIeType != "Gen3" || device.FirmwareVersion != "22.31.6" {
+		t.Fatalf("unexpected device: %+v", device)
+	}
+	if len(device.Functions) != 1 || device.Functions[0].VendorID != "0x14e4" || device.Functions[0].DeviceID != "0x16d7" || device.Functions[0].DeviceClass != "NetworkController" {
+		t.Fatalf("unexpected functions: %+v", device.Functions)
+	}
+}
@@ -0,0 +1,259 @@
+package redfishapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGracefulShutdownWithFallbackDellEscalatesToForceOff(t *testing.T) {
+	fastTaskPolling(t)
+	var (
+		mu         sync.Mutex
+		resetTypes []string
+		powerState = "On"
+	)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1":
+			fmt.Fprintf(w, `{"PowerState":%q}`, powerState)
+		case "/redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset":
+			var payload struct {
+				ResetType string
+			}
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &payload)
+			resetTypes = append(resetTypes, payload.ResetType)
+			// the OS ignores the graceful shutdown request
+			if payload.ResetType == "ForceOff" {
+				powerState = "Off"
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	result, err := provider.GracefulShutdownWithFallbackDell(10 * time.Millisecond)
+	if err != nil {
+		t.Fatalf("GracefulShutdownWithFallbackDell returned error: %v", err)
+	}
+	if result != "Server ForceOff" {
+		t.Fatalf("unexpected result: %q", result)
+	}
+	if len(resetTypes) != 2 || resetTypes[0] != "GracefulShutdown" || resetTypes[1] != "ForceOff" {
+		t.Fatalf("unexpected reset actions: %v", resetTypes)
+	}
+}
+
+func TestWaitForPowerStateDellRejectsUnknownState(t *testing.T) {
+	provider := &redfishProvider{Hostname: "https://127.0.0.1:0", Username: "user", Password: "pass"}
+	if err := provider.WaitForPowerStateDell("Standby", time.Second); err == nil {
+		t.Fatalf("expected error for unknown power state")
+	}
+}
+
+func TestGetPowerMetricsDellReadsPowerControl(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Chassis/System.Embedded.1/Power":
+			fmt.Fprint(w, `{"PowerControl":[{"PowerConsumedWatts":312,"PowerCapacityWatts":1600,"PowerLimit":{"LimitInWatts":null,"LimitException":"HardPowerOff"},"PowerMetrics":{"AverageConsumedWatts":298.5,"IntervalInMin":60,"MaxConsumedWatts":402,"MinConsumedWatts":254}}],"PowerSupplies":[{"Name":"PS1 Status","PowerInputWatts":166.5,"PowerOutputWatts":150,"LineInputVoltage":230.5,"LineInputVoltageType":"AC240V","Status":{"Health":"OK","State":"Enabled"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	metrics, err := provider.GetPowerMetricsDell()
+	if err != nil {
+		t.Fatalf("GetPowerMetricsDell returned error: %v", err)
+	}
+	if metrics.PowerConsumedWatts != 312 || metrics.AverageConsumedWatts != 298.5 || metrics.IntervalInMin != 60 || metrics.PowerLimitEnabled {
+		t.Fatalf("unexpected power metrics: %+v", metrics)
+	}
+	if len(metrics.PowerSupplies) != 1 || metrics.PowerSupplies[0].PowerInputWatts != 166.5 || metrics.PowerSupplies[0].LineInputVoltage != 230.5 {
+		t.Fatalf("unexpected power supplies: %+v", metrics.PowerSupplies)
+	}
+}
+
+func TestSetPowerLimitDellRejectsLimitAboveCapacity(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/Chassis/System.Embedded.1/Power" && r.Method == "GET":
+			fmt.Fprint(w, `{"PowerControl":[{"PowerConsumedWatts":312,"PowerCapacityWatts":750}]}`)
+		case r.Method == "PATCH":
+			t.Fatalf("nothing should be patched for a power limit above the capacity")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.SetPowerLimitDell(900); err == nil {
+		t.Fatalf("expected error for power limit above capacity")
+	}
+}
+
+func TestSetPowerLimitDellReadsCapacityOnce(t *testing.T) {
+	var powerReads, patches int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/Chassis/System.Embedded.1/Power" && r.Method == "GET":
+			powerReads++
+			fmt.Fprint(w, `{"PowerControl":[{"PowerConsumedWatts":312,"PowerCapacityWatts":750}]}`)
+		case r.Method == "PATCH":
+			patches++
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.SetPowerLimitDell(500); err != nil {
+		t.Fatalf("SetPowerLimitDell returned error: %v", err)
+	}
+	if powerReads != 1 || patches != 2 {
+		t.Fatalf("expected one capacity read and two patches, got %d reads and %d patches", powerReads, patches)
+	}
+}
+
+func TestSetPowerLimitDellEnablesCappingAfterTheLimit(t *testing.T) {
+	var patched []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/Chassis/System.Embedded.1/Power" && r.Method == "GET":
+			fmt.Fprint(w, `{"PowerControl":[{"PowerConsumedWatts":312,"PowerCapacityWatts":750}]}`)
+		case r.Method == "PATCH":
+			patched = append(patched, r.URL.Path)
+			if r.URL.Path == "/redfish/v1/Chassis/System.Embedded.1/Power" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.SetPowerLimitDell(500); err == nil {
+		t.Fatalf("expected error for a rejected power limit")
+	}
+	if len(patched) != 1 || patched[0] != "/redfish/v1/Chassis/System.Embedded.1/Power" {
+		t.Fatalf("power capping should stay untouched when the limit is rejected, patched %v", patched)
+	}
+}
+
+func TestGetPowerMetricsHPKeepsPowerControlWhenOff(t *testing.T) {
+	var powerReads int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Chassis/1/Power/":
+			powerReads++
+			fmt.Fprint(w, `{"PowerConsumedWatts":0,"PowerCapacityWatts":0,"PowerControl":[{"PowerConsumedWatts":0,"PowerCapacityWatts":1600,"PowerMetrics":{"AverageConsumedWatts":215,"IntervalInMin":20,"MaxConsumedWatts":390,"MinConsumedWatts":0}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	metrics, err := provider.GetPowerMetricsHP()
+	if err != nil {
+		t.Fatalf("GetPowerMetricsHP returned error: %v", err)
+	}
+	if powerReads != 1 || metrics.PowerCapacityWatts != 1600 || metrics.AverageConsumedWatts != 215 || metrics.IntervalInMin != 20 {
+		t.Fatalf("unexpected metrics after %d reads: %+v", powerReads, metrics)
+	}
+}
+
+func TestGetPowerMetricsHPReadsTopLevelOnOldILO4(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Chassis/1/Power/":
+			fmt.Fprint(w, `{"PowerConsumedWatts":180,"PowerCapacityWatts":1000,"PowerMetrics":{"AverageConsumedWatts":175,"IntervalInMin":20,"MaxConsumedWatts":240,"MinConsumedWatts":160}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	metrics, err := provider.GetPowerMetricsHP()
+	if err != nil {
+		t.Fatalf("GetPowerMetricsHP returned error: %v", err)
+	}
+	if metrics.PowerConsumedWatts != 180 || metrics.PowerCapacityWatts != 1000 || metrics.MaxConsumedWatts != 240 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+}
+
+func TestGracefulShutdownWithFallbackDellReturnsWaitErrors(t *testing.T) {
+	fastTaskPolling(t)
+	var (
+		mu         sync.Mutex
+		resetTypes []string
+	)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1":
+			// the credentials were changed while shutting down
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset":
+			var payload struct {
+				ResetType string
+			}
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &payload)
+			resetTypes = append(resetTypes, payload.ResetType)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.GracefulShutdownWithFallbackDell(time.Minute); err == nil || err.Error() != StatusUnauthorized {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+	if len(resetTypes) != 1 || resetTypes[0] != "GracefulShutdown" {
+		t.Fatalf("the server should not be forced off, reset actions: %v", resetTypes)
+	}
+}
+
+func TestGracefulShutdownWithFallbackHPSkipsServerThatIsOff(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/1":
+			fmt.Fprint(w, `{"Power":"Off","PowerState":"Off","Actions":{"#ComputerSystem.Reset":{"ResetType@Redfish.AllowableValues":["On","ForceOff","PushPowerButton"]}}}`)
+		default:
+			t.Fatalf("no power action should be sent to a server that is Off: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	result, err := provider.GracefulShutdownWithFallbackHP(time.Minute)
+	if err != nil || result != "Server Off" {
+		t.Fatalf("unexpected result %q, error: %v", result, err)
+	}
+}
@@ -0,0 +1,63 @@
+package redfishapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetProcessorInventoryDellReadsCoresAndSpeed(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1/Processors":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Processors/CPU.Socket.1"}]}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Processors/CPU.Socket.1":
+			fmt.Fprint(w, `{"Id":"CPU.Socket.1","Socket":"CPU.Socket.1","Manufacturer":"Intel","Model":"Intel(R) Xeon(R) Gold 6338 CPU @ 2.00GHz","TotalCores":32,"TotalThreads":64,"MaxSpeedMHz":4000,"ProcessorId":{"MicrocodeInfo":"0xd000375"},"Oem":{"Dell":{"DellProcessor":{"CurrentClockSpeedMhz":2000}}},"Status":{"Health":"OK","State":"Enabled"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	processors, err := provider.GetProcessorInventoryDell()
+	if err != nil {
+		t.Fatalf("GetProcessorInventoryDell returned error: %v", err)
+	}
+	if len(processors) != 1 {
+		t.Fatalf("expected 1 processor, got %+v", processors)
+	}
+	cpu := processors[0]
+	if cpu.Socket != "CPU.Socket.1" || cpu.Cores != 32 || cpu.Threads != 64 || cpu.MaxSpeedMHz != 4000 || cpu.CurrentSpeedMHz != 2000 || cpu.Microcode != "0xd000375" || cpu.Health != "OK" {
+		t.Fatalf("unexpected processor: %+v", cpu)
+	}
+}
+
+func TestGetProcessorInventoryHPReadsOemDetails(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/1/Processors/":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/1/Processors/1/"}]}`)
+		case "/redfish/v1/Systems/1/Processors/1/":
+			fmt.Fprint(w, `{"Id":"1","Socket":"Proc 1","Manufacturer":"Intel","Model":" Intel(R) Xeon(R) CPU E5-2680 v4 @ 2.40GHz","TotalCores":14,"TotalThreads":28,"MaxSpeedMHz":4000,"OperatingSpeedMHz":2900,"Oem":{"Hp":{"RatedSpeedMHz":2400,"ConfigStatus":{"State":"Enabled"},"MicrocodePatches":[{"PatchId":"0x0B000021"},{"PatchId":"0x0B000038"}]}},"Status":{"Health":"OK"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	processors, err := provider.GetProcessorInventoryHP()
+	if err != nil {
+		t.Fatalf("GetProcessorInventoryHP returned error: %v", err)
+	}
+	if len(processors) != 1 {
+		t.Fatalf("expected 1 processor, got %+v", processors)
+	}
+	cpu := processors[0]
+	// RatedSpeedMHz is the nominal speed, not the current one
+	if cpu.Socket != "Proc 1" || cpu.Model != "Intel(R) Xeon(R) CPU E5-2680 v4 @ 2.40GHz" || cpu.Cores != 14 || cpu.CurrentSpeedMHz != 2900 || cpu.Microcode != "0x0B000038" || cpu.State != "Enabled" {
+		t.Fatalf("unexpected processor: %+v", cpu)
+	}
+}
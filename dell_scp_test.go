@@ -0,0 +1,122 @@
+package redfishapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExportSCPDellWaitsForTaskAndReturnsProfile(t *testing.T) {
+	fastTaskPolling(t)
+	var taskHits atomic.Int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Actions/Oem/EID_674_Manager.ExportSystemConfiguration":
+			w.Header().Set("Location", "/redfish/v1/TaskService/Tasks/JID_1")
+			w.WriteHeader(http.StatusAccepted)
+		case "/redfish/v1/TaskService/Tasks/JID_1":
+			if taskHits.Add(1) == 1 {
+				w.WriteHeader(http.StatusAccepted)
+				fmt.Fprint(w, `{"Id":"JID_1","TaskState":"Running","PercentComplete":20}`)
+				return
+			}
+			fmt.Fprint(w, `<SystemConfiguration Model="PowerEdge R750"></SystemConfiguration>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var percents []int
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	profile, err := provider.ExportSCPDell("ALL", "xml", []string{"IncludeReadOnly"}, time.Minute, func(percent int, message string) {
+		percents = append(percents, percent)
+	})
+	if err != nil {
+		t.Fatalf("ExportSCPDell returned error: %v", err)
+	}
+	if string(profile) != `<SystemConfiguration Model="PowerEdge R750"></SystemConfiguration>` {
+		t.Fatalf("unexpected profile: %s", profile)
+	}
+	if len(percents) != 1 || percents[0] != 20 {
+		t.Fatalf("unexpected progress reports: %v", percents)
+	}
+}
+
+func TestPreviewImportSCPDellReturnsAttributeResults(t *testing.T) {
+	fastTaskPolling(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Managers/iDRAC.Embedded.1/Actions/Oem/EID_674_Manager.ImportSystemConfigurationPreview":
+			w.Header().Set("Location", "/redfish/v1/TaskService/Tasks/JID_2")
+			w.WriteHeader(http.StatusAccepted)
+		case "/redfish/v1/TaskService/Tasks/JID_2":
+			fmt.Fprint(w, `{"Id":"JID_2","TaskState":"Completed","TaskStatus":"OK","Messages":[{"Message":"Successfully previewed Server Configuration Profile import operation.","MessageId":"SYS081"},{"Message":"The value of BootMode is valid.","MessageId":"SYS067","Oem":{"Dell":{"FQDD":"BIOS.Setup.1-1","Name":"BootMode","OldValue":"Bios","NewValue":"Uefi","Status":"Success","ErrCode":0}}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	result, err := provider.PreviewImportSCPDell([]byte(`<SystemConfiguration></SystemConfiguration>`), "ALL", time.Minute, nil)
+	if err != nil {
+		t.Fatalf("PreviewImportSCPDell returned error: %v", err)
+	}
+	if result.TaskState != TaskStateCompleted || len(result.Results) != 1 {
+		t.Fatalf("unexpected preview result: %+v", result)
+	}
+	if result.Results[0].FQDD != "BIOS.Setup.1-1" || result.Results[0].Name != "BootMode" || result.Results[0].NewValue != "Uefi" {
+		t.Fatalf("unexpected attribute result: %+v", result.Results[0])
+	}
+}
+
+func TestBuildSCPImportDellOnlyIncludesChangedAttributes(t *testing.T) {
+	var current, desired ExportConfigResponse
+	json.Unmarshal([]byte(`{"SystemConfiguration":{"Components":[{"FQDD":"BIOS.Setup.1-1","Attributes":[{"Name":"BootMode","Value":"Bios"},{"Name":"SysProfile","Value":"PerfOptimized"}]},{"FQDD":"iDRAC.Embedded.1","Attributes":[{"Name":"NIC.1#DNSRacName","Value":"old-name"},{"Name":"Info.1#Version","Value":"7.00","Comment":"Read Only"}]}]}}`), &current)
+	json.Unmarshal([]byte(`{"SystemConfiguration":{"Components":[{"FQDD":"BIOS.Setup.1-1","Attributes":[{"Name":"BootMode","Value":"Uefi"},{"Name":"SysProfile","Value":"PerfOptimized"}]},{"FQDD":"iDRAC.Embedded.1","Attributes":[{"Name":"NIC.1#DNSRacName","Value":"{{.hostname}}-idrac"},{"Name":"Info.1#Version","Value":"7.10","Comment":"Read Only"}]}]}}`), &desired)
+
+	rendered, err := RenderSCPTemplateDell(desired, map[string]string{"hostname": "node01"})
+	if err != nil {
+		t.Fatalf("RenderSCPTemplateDell returned error: %v", err)
+	}
+	if desired.SystemConfiguration.Components[1].Attributes[0].Value != "{{.hostname}}-idrac" {
+		t.Fatalf("expected template to be left untouched")
+	}
+
+	diffs := DiffSCPDell(current, rendered)
+	if len(diffs) != 3 {
+		t.Fatalf("expected 3 diffs, got %+v", diffs)
+	}
+	if diffs[1].Name != "NIC.1#DNSRacName" || diffs[1].OldValue != "old-name" || diffs[1].NewValue != "node01-idrac" || diffs[1].Change != "Changed" {
+		t.Fatalf("unexpected diff: %+v", diffs[1])
+	}
+
+	payload, err := BuildSCPImportDell(current, rendered, "ALL")
+	if err != nil {
+		t.Fatalf("BuildSCPImportDell returned error: %v", err)
+	}
+	var importConfig struct {
+		ImportBuffer string
+	}
+	json.Unmarshal(payload, &importConfig)
+	expected := `<SystemConfiguration><Component FQDD="BIOS.Setup.1-1"><Attribute Name="BootMode">Uefi</Attribute></Component><Component FQDD="iDRAC.Embedded.1"><Attribute Name="NIC.1#DNSRacName">node01-idrac</Attribute></Component></SystemConfiguration>`
+	if importConfig.ImportBuffer != expected {
+		t.Fatalf("unexpected ImportBuffer: %s", importConfig.ImportBuffer)
+	}
+}
+
+func TestRenderSCPTemplateDellFailsOnMissingVariable(t *testing.T) {
+	var profile ExportConfigResponse
+	json.Unmarshal([]byte(`{"SystemConfiguration":{"Components":[{"FQDD":"System.Embedded.1","Attributes":[{"Name":"ServerInfo.1#AssetTag","Value":"{{.assettag}}"}]}]}}`), &profile)
+
+	if _, err := RenderSCPTemplateDell(profile, map[string]string{"hostname": "node01"}); err == nil {
+		t.Fatalf("expected error for missing template variable")
+	}
+}
@@ -0,0 +1,91 @@
+package redfishapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetThermalReadingsDellReadsThresholds(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Chassis/System.Embedded.1/Thermal":
+			fmt.Fprint(w, `{"Temperatures":[{"Name":"CPU1 Temp","ReadingCelsius":54,"UpperThresholdCritical":95,"UpperThresholdFatal":100,"UpperThresholdNonCritical":null,"PhysicalContext":"CPU","Status":{"Health":"OK","State":"Enabled"}}],"Fans":[{"Name":"System Board Fan1A","Reading":7320,"ReadingUnits":"RPM","LowerThresholdCritical":480,"PhysicalContext":"SystemBoard","Status":{"Health":"OK","State":"Enabled"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	readings, err := provider.GetThermalReadingsDell()
+	if err != nil {
+		t.Fatalf("GetThermalReadingsDell returned error: %v", err)
+	}
+	if len(readings) != 2 {
+		t.Fatalf("expected 2 readings, got %+v", readings)
+	}
+	cpu := readings[0]
+	if cpu.Type != "Temperature" || *cpu.Reading != 54 || cpu.Units != "Cel" || cpu.UpperThresholdCritical == nil || *cpu.UpperThresholdCritical != 95 || cpu.UpperThresholdNonCritical != nil {
+		t.Fatalf("unexpected temperature reading: %+v", cpu)
+	}
+	fan := readings[1]
+	if fan.Type != "Fan" || *fan.Reading != 7320 || fan.Units != "RPM" || fan.LowerThresholdCritical == nil || *fan.LowerThresholdCritical != 480 {
+		t.Fatalf("unexpected fan reading: %+v", fan)
+	}
+}
+
+func TestGetThermalReadingsDellFallsBackToSensors(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Chassis/System.Embedded.1/Sensors":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Chassis/System.Embedded.1/Sensors/InletTemp"},{"@odata.id":"/redfish/v1/Chassis/System.Embedded.1/Sensors/Fan1A"},{"@odata.id":"/redfish/v1/Chassis/System.Embedded.1/Sensors/PS1Voltage"}]}`)
+		case "/redfish/v1/Chassis/System.Embedded.1/Sensors/InletTemp":
+			fmt.Fprint(w, `{"Name":"System Board Inlet Temp","ReadingType":"Temperature","Reading":23,"ReadingUnits":"Cel","Thresholds":{"UpperCaution":{"Reading":38},"UpperCritical":{"Reading":42}},"Status":{"Health":"OK","State":"Enabled"}}`)
+		case "/redfish/v1/Chassis/System.Embedded.1/Sensors/Fan1A":
+			fmt.Fprint(w, `{"Name":"System Board Fan1A","ReadingType":"Rotational","Reading":5400,"ReadingUnits":"RPM","Thresholds":{"LowerCritical":{"Reading":600}},"Status":{"Health":"OK","State":"Enabled"}}`)
+		case "/redfish/v1/Chassis/System.Embedded.1/Sensors/PS1Voltage":
+			fmt.Fprint(w, `{"Name":"PS1 Voltage 1","ReadingType":"Voltage","Reading":230,"ReadingUnits":"V"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	readings, err := provider.GetThermalReadingsDell()
+	if err != nil {
+		t.Fatalf("GetThermalReadingsDell returned error: %v", err)
+	}
+	if len(readings) != 2 {
+		t.Fatalf("expected 2 readings, got %+v", readings)
+	}
+	if readings[0].Type != "Temperature" || *readings[0].Reading != 23 || *readings[0].UpperThresholdNonCritical != 38 || *readings[0].UpperThresholdCritical != 42 {
+		t.Fatalf("unexpected temperature reading: %+v", readings[0])
+	}
+	if readings[1].Type != "Fan" || *readings[1].Reading != 5400 || *readings[1].LowerThresholdCritical != 600 {
+		t.Fatalf("unexpected fan reading: %+v", readings[1])
+	}
+}
+
+func TestGetThermalReadingsDellKeepsMissingReadingNil(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Chassis/System.Embedded.1/Thermal":
+			fmt.Fprint(w, `{"Temperatures":[{"Name":"CPU2 Temp","ReadingCelsius":null,"Status":{"Health":"Critical","State":"Enabled"}},{"Name":"Exhaust Temp","ReadingCelsius":0,"Status":{"Health":"OK","State":"Enabled"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	readings, err := provider.GetThermalReadingsDell()
+	if err != nil {
+		t.Fatalf("GetThermalReadingsDell returned error: %v", err)
+	}
+	if len(readings) != 2 || readings[0].Reading != nil || readings[1].Reading == nil || *readings[1].Reading != 0 {
+		t.Fatalf("a missing reading should be nil and a real 0 kept: %+v", readings)
+	}
+}
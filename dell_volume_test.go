@@ -0,0 +1,240 @@
+package redfishapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const storageControllerDellJSON = `{"Id":"RAID.Integrated.1-1","Drives":[{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/Disk.Bay.0:Enclosure.Internal.0-1:RAID.Integrated.1-1"},{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/Disk.Bay.1:Enclosure.Internal.0-1:RAID.Integrated.1-1"}],"StorageControllers":[{"SupportedRAIDTypes":["RAID0","RAID1","RAID5"]}]}`
+
+func TestCreateVolumeDellPostsVolumeAndWaitsForJob(t *testing.T) {
+	fastTaskPolling(t)
+	var (
+		payload struct {
+			RAIDType         string
+			Name             string
+			ReadCachePolicy  string
+			WriteCachePolicy string
+			Links            struct {
+				Drives []Members
+			}
+		}
+		jobHits atomic.Int32
+	)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1":
+			fmt.Fprint(w, storageControllerDellJSON)
+		case r.URL.Path == "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Volumes" && r.Method == "POST":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &payload)
+			w.Header().Set("Location", "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_100")
+			w.WriteHeader(http.StatusAccepted)
+		case r.URL.Path == "/redfish/v1/JobService/Jobs/JID_100":
+			if jobHits.Add(1) == 1 {
+				fmt.Fprint(w, `{"Id":"JID_100","JobState":"Running","PercentComplete":50}`)
+				return
+			}
+			fmt.Fprint(w, `{"Id":"JID_100","JobState":"Completed","PercentComplete":100,"Message":"Job completed successfully."}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	jobURL, err := provider.CreateVolumeDell(VolumeRequest{
+		ControllerID:     "RAID.Integrated.1-1",
+		Name:             "os",
+		RAIDType:         "RAID1",
+		Drives:           []string{"Disk.Bay.0:Enclosure.Internal.0-1:RAID.Integrated.1-1", "Disk.Bay.1:Enclosure.Internal.0-1:RAID.Integrated.1-1"},
+		ReadCachePolicy:  "ReadAhead",
+		WriteCachePolicy: "WriteThrough",
+	})
+	if err != nil {
+		t.Fatalf("CreateVolumeDell returned error: %v", err)
+	}
+	if payload.RAIDType != "RAID1" || payload.Name != "os" || len(payload.Links.Drives) != 2 || payload.ReadCachePolicy != "ReadAhead" {
+		t.Fatalf("unexpected volume payload: %+v", payload)
+	}
+
+	job, err := provider.WaitForJobDell(jobURL, time.Minute, nil)
+	if err != nil {
+		t.Fatalf("WaitForJobDell returned error: %v", err)
+	}
+	if job.JobState != JobStateCompleted || jobHits.Load() != 2 {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+}
+
+func TestWaitForJobDellFailsOnTaskException(t *testing.T) {
+	fastTaskPolling(t)
+	var jobHits atomic.Int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/redfish/v1/JobService/Jobs/JID_101" {
+			http.NotFound(w, r)
+			return
+		}
+		jobHits.Add(1)
+		fmt.Fprint(w, `{"Id":"JID_101","JobState":"Exception","Message":"Unable to create the virtual disk."}`)
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.WaitForJobDell("JID_101", time.Minute, nil); err == nil || jobHits.Load() != 1 {
+		t.Fatalf("expected the Exception state to fail the job at once, got %v after %d polls", err, jobHits.Load())
+	}
+}
+
+func TestCreateVolumeDellRejectsUnsupportedRAIDType(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1":
+			fmt.Fprint(w, storageControllerDellJSON)
+		case r.Method == "POST":
+			t.Fatalf("no volume should be created for an unsupported RAID type")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	_, err := provider.CreateVolumeDell(VolumeRequest{
+		ControllerID: "RAID.Integrated.1-1",
+		RAIDType:     "RAID6",
+		Drives:       []string{"Disk.Bay.0:Enclosure.Internal.0-1:RAID.Integrated.1-1", "Disk.Bay.1:Enclosure.Internal.0-1:RAID.Integrated.1-1"},
+	})
+	if err == nil {
+		t.Fatalf("expected error for unsupported RAID type")
+	}
+}
+
+func TestAssignHotSpareDellPostsDedicatedSpare(t *testing.T) {
+	var payload struct {
+		TargetFQDD       string
+		VirtualDiskArray []string
+	}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1/Oem/Dell/DellRaidService/Actions/DellRaidService.AssignSpare":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &payload)
+			w.Header().Set("Location", "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_200")
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	jobURL, err := provider.AssignHotSpareDell("Disk.Bay.2:Enclosure.Internal.0-1:RAID.Integrated.1-1", []string{"Disk.Virtual.0:RAID.Integrated.1-1"})
+	if err != nil {
+		t.Fatalf("AssignHotSpareDell returned error: %v", err)
+	}
+	if jobURL != "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_200" {
+		t.Fatalf("unexpected job url: %q", jobURL)
+	}
+	if payload.TargetFQDD != "Disk.Bay.2:Enclosure.Internal.0-1:RAID.Integrated.1-1" || len(payload.VirtualDiskArray) != 1 {
+		t.Fatalf("unexpected spare payload: %+v", payload)
+	}
+}
+
+func TestSecureEraseDriveDellPostsDriveAction(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/Disk.Bay.0:Enclosure.Internal.0-1:RAID.Integrated.1-1/Actions/Drive.SecureErase":
+			w.Header().Set("Location", "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_300")
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	jobURL, err := provider.SecureEraseDriveDell("Disk.Bay.0:Enclosure.Internal.0-1:RAID.Integrated.1-1")
+	if err != nil {
+		t.Fatalf("SecureEraseDriveDell returned error: %v", err)
+	}
+	if jobURL != "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_300" {
+		t.Fatalf("unexpected job url: %q", jobURL)
+	}
+}
+
+func TestGetStorageControllerDetailsDellReadsOemController(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1/Storage":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1"}]}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1":
+			fmt.Fprint(w, `{"Id":"RAID.Integrated.1-1","Name":"PERC H755 Front","Oem":{"Dell":{"DellController":{"CacheSizeInMB":8192,"ControllerFirmwareVersion":"52.16.1-4405","CurrentControllerMode":"RAID","EncryptionCapability":"LocalKeyManagementCapable","SecurityStatus":"EncryptionCapable"},"DellControllerBattery":{"PrimaryStatus":"OK","RAIDState":"Ready"}}},"StorageControllers":[{"Model":"PERC H755 Front","SupportedRAIDTypes":["RAID0","RAID1","RAID5","RAID6","RAID10"]}],"Status":{"Health":"OK","State":"Enabled"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	controllers, err := provider.GetStorageControllerDetailsDell()
+	if err != nil {
+		t.Fatalf("GetStorageControllerDetailsDell returned error: %v", err)
+	}
+	if len(controllers) != 1 {
+		t.Fatalf("expected 1 controller, got %+v", controllers)
+	}
+	controller := controllers[0]
+	if controller.Model != "PERC H755 Front" || controller.CacheSizeMB != 8192 || controller.BatteryState != "OK" || controller.Mode != "RAID" || len(controller.SupportedRAIDTypes) != 5 {
+		t.Fatalf("unexpected controller: %+v", controller)
+	}
+}
+
+func TestGetStorageOperationsDellReadsStandardAndOemOperations(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/System.Embedded.1/Storage":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1"}]}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Volumes":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Volumes/Disk.Virtual.0:RAID.Integrated.1-1"},{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Volumes/Disk.Virtual.1:RAID.Integrated.1-1"},{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Volumes/Disk.Virtual.2:RAID.Integrated.1-1"},{"@odata.id":"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Volumes/Disk.Virtual.3:RAID.Integrated.1-1"}]}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Volumes/Disk.Virtual.0:RAID.Integrated.1-1":
+			fmt.Fprint(w, `{"Id":"Disk.Virtual.0:RAID.Integrated.1-1","Name":"os","Operations":[{"OperationName":"Rebuild","PercentageComplete":42}]}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Volumes/Disk.Virtual.1:RAID.Integrated.1-1":
+			fmt.Fprint(w, `{"Id":"Disk.Virtual.1:RAID.Integrated.1-1","Name":"data","Oem":{"Dell":{"DellVirtualDisk":{"OperationName":"Background Intialization","OperationPercentComplete":42.5}}}}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Volumes/Disk.Virtual.2:RAID.Integrated.1-1":
+			fmt.Fprint(w, `{"Id":"Disk.Virtual.2:RAID.Integrated.1-1","Name":"idle","Oem":{"Dell":{"DellVirtualDisk":{"OperationName":"None","OperationPercentComplete":0}}}}`)
+		case "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Volumes/Disk.Virtual.3:RAID.Integrated.1-1":
+			fmt.Fprint(w, `{"Id":"Disk.Virtual.3:RAID.Integrated.1-1","Name":"logs","Oem":{"Dell":{"DellVirtualDisk":{"OperationName":"Consistency Check","OperationPercentComplete":"13.7"}}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	operations, err := provider.GetStorageOperationsDell()
+	if err != nil {
+		t.Fatalf("GetStorageOperationsDell returned error: %v", err)
+	}
+	if len(operations) != 3 {
+		t.Fatalf("expected 3 operations, got %+v", operations)
+	}
+	if operations[0].Operation != "Rebuild" || operations[0].PercentComplete != 42 || operations[0].ControllerID != "RAID.Integrated.1-1" {
+		t.Fatalf("unexpected rebuild operation: %+v", operations[0])
+	}
+	if operations[1].VolumeName != "data" || operations[1].PercentComplete != 42 {
+		t.Fatalf("unexpected background init operation: %+v", operations[1])
+	}
+	if operations[2].VolumeName != "logs" || operations[2].PercentComplete != 13 {
+		t.Fatalf("unexpected consistency check operation: %+v", operations[2])
+	}
+}
@@ -2,22 +2,44 @@ package redfishapi
 
 import (
 	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+
+	ver "github.com/Masterminds/semver/v3"
 )
 
 // Initialize logger
 // var logger = log.New(os.Stdout, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile)
 
+// taskPollInterval ... time between two status checks while waiting on a task or job
+var taskPollInterval = 15 * time.Second
+
+// defaultTaskTimeout ... used by the calls which wait on a task without taking a timeout
+const defaultTaskTimeout = 30 * time.Minute
+
+// managerDownTimeout ... how long a manager which accepted a reset may keep answering before it restarts
+var managerDownTimeout = 2 * time.Minute
+
+// forceOffTimeout ... time given to a ForceOff to take effect
+const forceOffTimeout = 2 * time.Minute
+
 // basicAuth ... will create the basicauth encoded string for the credentials
 func basicAuth(username, password string) string {
 	auth := username + ":" + password
@@ -63,8 +85,10 @@ func queryData(c *redfishProvider, call string, link string, data []byte) ([]byt
 				err := errors.New(StatusUnauthorized)
 				return nil, resp.Header, resp.StatusCode, err
 			} else if resp.StatusCode == 400 {
+				// the body holds the extended info of the error
+				_body, _ := ioutil.ReadAll(resp.Body)
 				err := errors.New(StatusBadRequest)
-				return nil, resp.Header, resp.StatusCode, err
+				return _body, resp.Header, resp.StatusCode, err
 			}
 
 		}
@@ -133,6 +157,22 @@ func queryDataForce(c *redfishProvider, call string, link string, data []byte) (
 
 // postForm ... will make REST POST request with form data
 func postForm(c *redfishProvider, link string, form *bytes.Buffer, contentType string) ([]byte, http.Header, int, error) {
+	return postFormHeaders(c, link, form, contentType, nil)
+}
+
+// postFormHeaders ... will make REST POST request with form data and additional headers
+func postFormHeaders(c *redfishProvider, link string, form io.Reader, contentType string, headers map[string]string) ([]byte, http.Header, int, error) {
+	return postFormRequest(c, link, form, 0, contentType, headers, false)
+}
+
+// postFormStream ... will make REST POST request streaming the form from an io.Pipe, large images take longer than the
+// 300 seconds of postForm so only the wait for the response is limited, contentLength avoids a chunked upload when known
+func postFormStream(c *redfishProvider, link string, form io.Reader, contentLength int64, contentType string, headers map[string]string) ([]byte, http.Header, int, error) {
+	return postFormRequest(c, link, form, contentLength, contentType, headers, true)
+}
+
+// postFormRequest ... will make REST POST request with form data, see postFormHeaders and postFormStream
+func postFormRequest(c *redfishProvider, link string, form io.Reader, contentLength int64, contentType string, headers map[string]string, stream bool) ([]byte, http.Header, int, error) {
 
 	if c.Certificate != "" {
 		certPool := x509.NewCertPool()
@@ -146,12 +186,26 @@ func postForm(c *redfishProvider, link string, form *bytes.Buffer, contentType s
 		return nil, nil, 0, err
 	}
 
+	if contentLength > 0 {
+		req.ContentLength = contentLength
+	}
+
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Add("Authorization", "Basic "+basicAuth(c.Username, c.Password))
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
 
 	client := &http.Client{
 		Timeout: time.Second * 300,
 	}
+	if stream {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.ResponseHeaderTimeout = time.Second * 300
+		client = &http.Client{
+			Transport: transport,
+		}
+	}
 	resp, err := client.Do(req)
 	client.CloseIdleConnections()
 	if err != nil {
@@ -183,3 +237,1062 @@ func postForm(c *redfishProvider, link string, form *bytes.Buffer, contentType s
 
 	return respBody, resp.Header, resp.StatusCode, nil
 }
+
+// bootOverridePayload ... will validate the one time boot target and build the Boot PATCH payload
+func bootOverridePayload(target string, mode string, allowableTargets []string) ([]byte, error) {
+	if len(allowableTargets) > 0 && !slices.Contains(allowableTargets, target) {
+		return nil, fmt.Errorf("invalid boot target: %s, allowed values are %s", target, strings.Join(allowableTargets, ", "))
+	}
+	if mode != "" && mode != "UEFI" && mode != "Legacy" {
+		return nil, fmt.Errorf("invalid boot mode: %s", mode)
+	}
+
+	boot := map[string]interface{}{
+		"BootSourceOverrideTarget":  target,
+		"BootSourceOverrideEnabled": "Once",
+	}
+	if mode != "" {
+		boot["BootSourceOverrideMode"] = mode
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"Boot": boot,
+	})
+}
+
+// getBootOptions ... will fetch the standard BootOptions collection in Boot.BootOrder order
+func getBootOptions(c *redfishProvider, systemURL string) ([]BootOptionData, error) {
+	resp, _, _, err := queryData(c, "GET", c.Hostname+systemURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var system SystemBootOrder
+	json.Unmarshal(resp, &system)
+	if system.Boot.BootOptions.OdataID == "" {
+		return nil, fmt.Errorf("boot options are not supported on %s", systemURL)
+	}
+
+	resp, _, _, err = queryData(c, "GET", c.Hostname+system.Boot.BootOptions.OdataID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var members MemberCountDell
+	json.Unmarshal(resp, &members)
+
+	var bootOptions []BootOptionData
+	for i := range members.Members {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+members.Members[i].OdataId, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y BootOptionRaw
+		json.Unmarshal(resp, &y)
+
+		bootOption := BootOptionData{
+			ID:             y.ID,
+			Reference:      y.BootOptionReference,
+			DisplayName:    y.DisplayName,
+			Enabled:        y.BootOptionEnabled,
+			UefiDevicePath: y.UefiDevicePath,
+			Position:       slices.Index(system.Boot.BootOrder, y.BootOptionReference),
+		}
+		bootOptions = append(bootOptions, bootOption)
+	}
+
+	// options missing from Boot.BootOrder have position -1 and go last
+	slices.SortStableFunc(bootOptions, func(a, b BootOptionData) int {
+		if a.Position == -1 || b.Position == -1 {
+			return b.Position - a.Position
+		}
+		return a.Position - b.Position
+	})
+
+	return bootOptions, nil
+}
+
+// setBootOptionsOrder ... will PATCH Boot.BootOrder after validating the references against the BootOptions collection
+// Options which are not referenced keep their relative order after the referenced ones.
+func setBootOptionsOrder(c *redfishProvider, systemURL string, references []string) (string, error) {
+	bootOptions, err := getBootOptions(c, systemURL)
+	if err != nil {
+		return "", err
+	}
+
+	known := make([]string, 0, len(bootOptions))
+	for _, bootOption := range bootOptions {
+		known = append(known, bootOption.Reference)
+	}
+	for _, reference := range references {
+		if !slices.Contains(known, reference) {
+			return "", fmt.Errorf("unknown boot option reference: %s", reference)
+		}
+	}
+
+	order := slices.Clone(references)
+	for _, reference := range known {
+		if !slices.Contains(order, reference) {
+			order = append(order, reference)
+		}
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"Boot": map[string]interface{}{
+			"BootOrder": order,
+		},
+	})
+
+	_, _, status, err := queryData(c, "PATCH", c.Hostname+systemURL, data)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK && status != http.StatusAccepted && status != http.StatusNoContent {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return "Boot Order Updated", nil
+}
+
+// errWaitTimeout ... returned by waitUntil when the timeout expires
+var errWaitTimeout = errors.New("timed out")
+
+// waitUntil ... will call check every taskPollInterval until it reports ready, fails or the timeout expires
+func waitUntil(timeout time.Duration, what string, check func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		ready, err := check()
+		if err != nil {
+			return err
+		}
+		if ready {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w waiting for %s", errWaitTimeout, what)
+		}
+		time.Sleep(taskPollInterval)
+	}
+}
+
+// waitForManagerDown ... will wait, at most managerDownTimeout, for the service root of a manager which accepted a reset
+// to stop answering, a manager which doesn't go down within the bound was not restarting
+func waitForManagerDown(c *redfishProvider, rootURL string, what string) error {
+	err := waitUntil(managerDownTimeout, what+" to go down", func() (bool, error) {
+		_, _, status, err := queryDataForce(c, "GET", c.Hostname+rootURL, nil)
+		if err != nil && err.Error() == StatusUnauthorized {
+			return false, err
+		}
+		return err != nil || status != http.StatusOK, nil
+	})
+	if errors.Is(err, errWaitTimeout) {
+		return nil
+	}
+	return err
+}
+
+// managerAnswers ... will check that the manager answers the URL while it starts, only wrong credentials are returned as an error
+func managerAnswers(c *redfishProvider, url string) ([]byte, bool, error) {
+	resp, _, status, err := queryDataForce(c, "GET", url, nil)
+	if err != nil && err.Error() == StatusUnauthorized {
+		return nil, false, err
+	}
+	return resp, err == nil && status == http.StatusOK, nil
+}
+
+// gracefulShutdownWithFallback ... will run the graceful action, wait for Off and escalate to ForceOff after the timeout
+func gracefulShutdownWithFallback(timeout time.Duration, powerAction func(string) (string, error), waitForPowerState func(string, time.Duration) error, gracefulAction string) (string, error) {
+	if _, err := powerAction(gracefulAction); err != nil {
+		return "", err
+	}
+	// only a server that ignores the graceful action is forced off
+	if err := waitForPowerState("Off", timeout); err == nil {
+		return "Server " + gracefulAction, nil
+	} else if !errors.Is(err, errWaitTimeout) {
+		return "", err
+	}
+
+	if _, err := powerAction("ForceOff"); err != nil {
+		return "", err
+	}
+	if err := waitForPowerState("Off", forceOffTimeout); err != nil {
+		return "", err
+	}
+
+	return "Server ForceOff", nil
+}
+
+// getPowerMetrics ... will fetch the PowerControl readings and power supplies of the Chassis Power resource
+func getPowerMetrics(c *redfishProvider, powerURL string) (PowerMetricsData, error) {
+	resp, _, _, err := queryData(c, "GET", c.Hostname+powerURL, nil)
+	if err != nil {
+		return PowerMetricsData{}, err
+	}
+
+	var x PowerTelemetryRaw
+	json.Unmarshal(resp, &x)
+
+	var metrics PowerMetricsData
+	if len(x.PowerControl) > 0 {
+		powerControl := x.PowerControl[0]
+		metrics.PowerConsumedWatts = powerControl.PowerConsumedWatts
+		metrics.PowerCapacityWatts = powerControl.PowerCapacityWatts
+		metrics.AverageConsumedWatts = powerControl.PowerMetrics.AverageConsumedWatts
+		metrics.MinConsumedWatts = powerControl.PowerMetrics.MinConsumedWatts
+		metrics.MaxConsumedWatts = powerControl.PowerMetrics.MaxConsumedWatts
+		metrics.IntervalInMin = powerControl.PowerMetrics.IntervalInMin
+		metrics.PowerLimitException = powerControl.PowerLimit.LimitException
+		if powerControl.PowerLimit.LimitInWatts != nil && *powerControl.PowerLimit.LimitInWatts > 0 {
+			metrics.PowerLimitEnabled = true
+			metrics.PowerLimitWatts = *powerControl.PowerLimit.LimitInWatts
+		}
+	} else {
+		metrics.PowerConsumedWatts = x.PowerConsumedWatts
+		metrics.PowerCapacityWatts = x.PowerCapacityWatts
+		metrics.AverageConsumedWatts = x.PowerMetrics.AverageConsumedWatts
+		metrics.MinConsumedWatts = x.PowerMetrics.MinConsumedWatts
+		metrics.MaxConsumedWatts = x.PowerMetrics.MaxConsumedWatts
+		metrics.IntervalInMin = x.PowerMetrics.IntervalInMin
+	}
+
+	for _, powerSupply := range x.PowerSupplies {
+		outputWatts := powerSupply.PowerOutputWatts
+		if outputWatts == 0 {
+			outputWatts = powerSupply.LastPowerOutputWatts
+		}
+		metrics.PowerSupplies = append(metrics.PowerSupplies, PowerSupplyData{
+			Name:                 powerSupply.Name,
+			Model:                powerSupply.Model,
+			SerialNumber:         powerSupply.SerialNumber,
+			FirmwareVersion:      powerSupply.FirmwareVersion,
+			Health:               powerSupply.Status.Health,
+			State:                powerSupply.Status.State,
+			PowerCapacityWatts:   powerSupply.PowerCapacityWatts,
+			PowerInputWatts:      powerSupply.PowerInputWatts,
+			PowerOutputWatts:     outputWatts,
+			LineInputVoltage:     powerSupply.LineInputVoltage,
+			LineInputVoltageType: powerSupply.LineInputVoltageType,
+		})
+	}
+
+	return metrics, nil
+}
+
+// checkPowerLimit ... will validate the power limit against the PowerControl capacity
+func checkPowerLimit(c *redfishProvider, powerURL string, limitWatts int) error {
+	if limitWatts < 0 {
+		return fmt.Errorf("invalid power limit: %d", limitWatts)
+	}
+	if limitWatts == 0 {
+		return nil
+	}
+
+	metrics, err := getPowerMetrics(c, powerURL)
+	if err != nil {
+		return err
+	}
+	if metrics.PowerCapacityWatts > 0 && float64(limitWatts) > metrics.PowerCapacityWatts {
+		return fmt.Errorf("power limit %d exceeds the power capacity of %.0f watts", limitWatts, metrics.PowerCapacityWatts)
+	}
+
+	return nil
+}
+
+// setPowerLimit ... will validate and PATCH PowerControl LimitInWatts of the Chassis Power resource, 0 removes the limit
+func setPowerLimit(c *redfishProvider, powerURL string, limitWatts int) (string, error) {
+	if err := checkPowerLimit(c, powerURL, limitWatts); err != nil {
+		return "", err
+	}
+
+	return patchPowerLimit(c, powerURL, limitWatts)
+}
+
+// patchPowerLimit ... will PATCH PowerControl LimitInWatts of the Chassis Power resource without validating it
+func patchPowerLimit(c *redfishProvider, powerURL string, limitWatts int) (string, error) {
+	var limit interface{}
+	if limitWatts > 0 {
+		limit = limitWatts
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"PowerControl": []map[string]interface{}{
+			{
+				"PowerLimit": map[string]interface{}{
+					"LimitInWatts": limit,
+				},
+			},
+		},
+	})
+
+	_, _, status, err := queryData(c, "PATCH", c.Hostname+powerURL, data)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK && status != http.StatusAccepted && status != http.StatusNoContent {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	if limitWatts == 0 {
+		return "Power Limit Removed", nil
+	}
+	return fmt.Sprintf("Power Limit Set To %d Watts", limitWatts), nil
+}
+
+// getThermalReadings ... will fetch the temperature and fan readings of the chassis
+// falling back to the ThermalSubsystem/Sensors model when the Thermal resource is gone
+func getThermalReadings(c *redfishProvider, chassisURL string) ([]ThermalReadingData, error) {
+	resp, _, status, err := queryData(c, "GET", c.Hostname+chassisURL+"/Thermal", nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return getSensorReadings(c, chassisURL)
+	}
+
+	var x ThermalTelemetryRaw
+	json.Unmarshal(resp, &x)
+
+	var readings []ThermalReadingData
+	for _, temperature := range x.Temperatures {
+		reading := temperature.ReadingCelsius
+		if reading == nil {
+			reading = temperature.CurrentReading
+		}
+		readings = append(readings, ThermalReadingData{
+			Name:                      temperature.Name,
+			Type:                      "Temperature",
+			Reading:                   reading,
+			Units:                     "Cel",
+			PhysicalContext:           temperature.PhysicalContext,
+			Health:                    temperature.Status.Health,
+			State:                     temperature.Status.State,
+			UpperThresholdNonCritical: temperature.UpperThresholdNonCritical,
+			UpperThresholdCritical:    temperature.UpperThresholdCritical,
+			UpperThresholdFatal:       temperature.UpperThresholdFatal,
+			LowerThresholdNonCritical: temperature.LowerThresholdNonCritical,
+			LowerThresholdCritical:    temperature.LowerThresholdCritical,
+			LowerThresholdFatal:       temperature.LowerThresholdFatal,
+		})
+	}
+
+	for _, fan := range x.Fans {
+		// iLO 4 uses FanName, CurrentReading and Units
+		name, reading, units := fan.Name, fan.Reading, fan.ReadingUnits
+		if name == "" {
+			name = fan.FanName
+		}
+		if reading == nil {
+			reading = fan.CurrentReading
+		}
+		if units == "" {
+			units = fan.Units
+		}
+		readings = append(readings, ThermalReadingData{
+			Name:                      name,
+			Type:                      "Fan",
+			Reading:                   reading,
+			Units:                     units,
+			PhysicalContext:           fan.PhysicalContext,
+			Health:                    fan.Status.Health,
+			State:                     fan.Status.State,
+			UpperThresholdNonCritical: fan.UpperThresholdNonCritical,
+			UpperThresholdCritical:    fan.UpperThresholdCritical,
+			UpperThresholdFatal:       fan.UpperThresholdFatal,
+			LowerThresholdNonCritical: fan.LowerThresholdNonCritical,
+			LowerThresholdCritical:    fan.LowerThresholdCritical,
+			LowerThresholdFatal:       fan.LowerThresholdFatal,
+		})
+	}
+
+	return readings, nil
+}
+
+// getSensorReadings ... will fetch the temperature and fan readings from the Chassis Sensors collection
+func getSensorReadings(c *redfishProvider, chassisURL string) ([]ThermalReadingData, error) {
+	resp, _, status, err := queryData(c, "GET", c.Hostname+chassisURL+"/Sensors", nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch thermal readings, last status: %d", status)
+	}
+
+	var members MemberCountDell
+	json.Unmarshal(resp, &members)
+
+	var readings []ThermalReadingData
+	for i := range members.Members {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+members.Members[i].OdataId, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y SensorRaw
+		json.Unmarshal(resp, &y)
+
+		var readingType string
+		switch {
+		case y.ReadingType == "Temperature":
+			readingType = "Temperature"
+		case y.ReadingType == "Rotational", y.ReadingType == "Percent" && y.PhysicalContext == "Fan":
+			readingType = "Fan"
+		default:
+			continue
+		}
+
+		readings = append(readings, ThermalReadingData{
+			Name:                      y.Name,
+			Type:                      readingType,
+			Reading:                   y.Reading,
+			Units:                     y.ReadingUnits,
+			PhysicalContext:           y.PhysicalContext,
+			Health:                    y.Status.Health,
+			State:                     y.Status.State,
+			UpperThresholdNonCritical: y.Thresholds.UpperCaution.Reading,
+			UpperThresholdCritical:    y.Thresholds.UpperCritical.Reading,
+			UpperThresholdFatal:       y.Thresholds.UpperFatal.Reading,
+			LowerThresholdNonCritical: y.Thresholds.LowerCaution.Reading,
+			LowerThresholdCritical:    y.Thresholds.LowerCritical.Reading,
+			LowerThresholdFatal:       y.Thresholds.LowerFatal.Reading,
+		})
+	}
+
+	return readings, nil
+}
+
+// getMemoryInventory ... will fetch the installed DIMMs of the system, empty slots are left out
+func getMemoryInventory(c *redfishProvider, systemURL string) ([]MemoryData, error) {
+	resp, _, _, err := queryData(c, "GET", c.Hostname+systemURL+"/Memory", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var members MemberCountDell
+	json.Unmarshal(resp, &members)
+
+	var dimms []MemoryData
+	for i := range members.Members {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+members.Members[i].OdataId, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y MemoryRaw
+		json.Unmarshal(resp, &y)
+
+		// iLO 4 reports the DIMM state in DIMMStatus and the iLO 5 in Oem.Hpe.DIMMStatus
+		dimmStatus := y.DIMMStatus
+		if dimmStatus == "" {
+			dimmStatus = y.Oem.Hpe.DIMMStatus
+		}
+		if y.Status.State == "Absent" || dimmStatus == "NotPresent" {
+			continue
+		}
+
+		dimm := MemoryData{
+			Slot:            y.DeviceLocator,
+			Name:            y.Name,
+			CapacityMiB:     y.CapacityMiB,
+			SpeedMHz:        y.OperatingSpeedMhz,
+			Manufacturer:    strings.TrimSpace(y.Manufacturer),
+			PartNumber:      strings.TrimSpace(y.PartNumber),
+			SerialNumber:    strings.TrimSpace(y.SerialNumber),
+			MemoryType:      y.MemoryDeviceType,
+			ErrorCorrection: y.ErrorCorrection,
+			Health:          y.Status.Health,
+			State:           y.Status.State,
+		}
+		if dimm.Slot == "" {
+			dimm.Slot = strings.TrimSpace(y.SocketLocator)
+		}
+		if dimm.CapacityMiB == 0 {
+			dimm.CapacityMiB = y.SizeMB
+		}
+		if dimm.SpeedMHz == 0 {
+			dimm.SpeedMHz = y.MaximumFrequency
+		}
+		if dimm.MemoryType == "" {
+			dimm.MemoryType = y.DIMMType
+		}
+		if dimm.State == "" {
+			dimm.State = dimmStatus
+		}
+
+		if y.Metrics.OdataID != "" {
+			resp, _, status, err := queryData(c, "GET", c.Hostname+y.Metrics.OdataID, nil)
+			if err != nil {
+				return nil, err
+			}
+			if status == http.StatusOK {
+				var metrics MemoryMetricsRaw
+				json.Unmarshal(resp, &metrics)
+
+				dimm.CorrectableErrors = metrics.LifeTime.CorrectableECCErrorCount
+				if dimm.CorrectableErrors == nil {
+					dimm.CorrectableErrors = metrics.CurrentPeriod.CorrectableECCErrorCount
+				}
+				dimm.UncorrectableErrors = metrics.LifeTime.UncorrectableECCErrorCount
+				if dimm.UncorrectableErrors == nil {
+					dimm.UncorrectableErrors = metrics.CurrentPeriod.UncorrectableECCErrorCount
+				}
+			}
+		}
+
+		dimms = append(dimms, dimm)
+	}
+
+	return dimms, nil
+}
+
+// memoryHealth ... will reduce the DIMM inventory to a HealthList
+func memoryHealth(dimms []MemoryData) []HealthList {
+	var _health []HealthList
+	for _, dimm := range dimms {
+		_health = append(_health, HealthList{
+			Name:   dimm.Slot,
+			Health: dimm.Health,
+			State:  dimm.State,
+		})
+	}
+	return _health
+}
+
+// getPCIeDevices ... will fetch the PCIe devices with their functions, the devices are linked
+// from the System resource and on newer firmware collected under the Chassis
+func getPCIeDevices(c *redfishProvider, systemURL, chassisURL string) ([]PCIeDeviceData, error) {
+	resp, _, _, err := queryData(c, "GET", c.Hostname+systemURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var links PCIeDeviceLinksRaw
+	json.Unmarshal(resp, &links)
+
+	deviceLinks := links.PCIeDevices
+	if len(deviceLinks) == 0 {
+		resp, _, status, err := queryData(c, "GET", c.Hostname+chassisURL+"/PCIeDevices", nil)
+		if err != nil {
+			return nil, err
+		}
+		if status == http.StatusOK {
+			var members MemberCountDell
+			json.Unmarshal(resp, &members)
+			deviceLinks = members.Members
+		}
+	}
+
+	var devices []PCIeDeviceData
+	for i := range deviceLinks {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+deviceLinks[i].OdataId, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y PCIeDeviceRaw
+		json.Unmarshal(resp, &y)
+
+		device := PCIeDeviceData{
+			ID:              y.ID,
+			Name:            y.Name,
+			Slot:            y.Slot.Location.PartLocation.ServiceLabel,
+			Manufacturer:    y.Manufacturer,
+			Model:           y.Model,
+			DeviceType:      y.DeviceType,
+			SerialNumber:    y.SerialNumber,
+			PartNumber:      y.PartNumber,
+			FirmwareVersion: y.FirmwareVersion,
+			PCIeType:        y.PCIeInterface.PCIeType,
+			MaxPCIeType:     y.PCIeInterface.MaxPCIeType,
+			LanesInUse:      y.PCIeInterface.LanesInUse,
+			MaxLanes:        y.PCIeInterface.MaxLanes,
+			Health:          y.Status.Health,
+			State:           y.Status.State,
+		}
+		if device.Slot == "" && y.Slot.Location.PartLocation.LocationOrdinalValue != nil {
+			device.Slot = strconv.Itoa(*y.Slot.Location.PartLocation.LocationOrdinalValue)
+		}
+
+		functionLinks := y.Links.PCIeFunctions
+		if len(functionLinks) == 0 && y.PCIeFunctions.OdataID != "" {
+			resp, _, _, err := queryData(c, "GET", c.Hostname+y.PCIeFunctions.OdataID, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var members MemberCountDell
+			json.Unmarshal(resp, &members)
+			functionLinks = members.Members
+		}
+
+		for j := range functionLinks {
+			resp, _, _, err := queryData(c, "GET", c.Hostname+functionLinks[j].OdataId, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var z PCIeFunctionRaw
+			json.Unmarshal(resp, &z)
+
+			device.Functions = append(device.Functions, PCIeFunctionData{
+				ID:                z.ID,
+				Name:              z.Name,
+				FunctionID:        z.FunctionID,
+				FunctionType:      z.FunctionType,
+				DeviceClass:       z.DeviceClass,
+				ClassCode:         z.ClassCode,
+				VendorID:          z.VendorID,
+				DeviceID:          z.DeviceID,
+				SubsystemVendorID: z.SubsystemVendorID,
+				SubsystemID:       z.SubsystemID,
+				RevisionID:        z.RevisionID,
+				Health:            z.Status.Health,
+				State:             z.Status.State,
+			})
+		}
+
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
+
+// standardDriveData ... will map a standard Redfish Drive to the common drive type
+// the power on hours are only exposed through the Drive Metrics on newer firmware
+func standardDriveData(c *redfishProvider, z StorageDriveDetailsDell, controllerID string) (StorageDriveData, error) {
+	drive := StorageDriveData{
+		ID:                 z.ID,
+		Name:               z.Name,
+		ControllerID:       controllerID,
+		Location:           z.Name,
+		Model:              strings.TrimSpace(z.Model),
+		SerialNumber:       strings.TrimSpace(z.SerialNumber),
+		FirmwareVersion:    z.Revision,
+		MediaType:          z.MediaType,
+		Protocol:           z.Protocol,
+		CapacityBytes:      int64(z.CapacityBytes),
+		RotationSpeedRPM:   z.RotationSpeedRPM,
+		Health:             z.Status.Health,
+		State:              z.Status.State,
+		NegotiatedSpeedGbs: z.NegotiatedSpeedGbsFloat,
+		FailurePredicted:   z.FailurePredicted,
+	}
+
+	if lifeLeft, ok := z.PredictedMediaLifeLeftPercent.(float64); ok {
+		drive.PredictedMediaLifeLeftPercent = &lifeLeft
+	}
+
+	predictiveState := z.Oem.Dell.DellPhysicalDisk.PredictiveFailureState
+	switch {
+	case predictiveState != "" && predictiveState != "SmartAlertAbsent":
+		drive.FailurePredicted = true
+		drive.FailureReason = predictiveState
+	case z.Oem.Dell.DellPhysicalDisk.RaidStatus == "Failed":
+		drive.FailureReason = "Failed"
+	case z.FailurePredicted:
+		drive.FailureReason = "FailurePredicted"
+	}
+
+	if z.Metrics.OdataId != "" {
+		resp, _, status, err := queryData(c, "GET", c.Hostname+z.Metrics.OdataId, nil)
+		if err != nil {
+			return drive, err
+		}
+		if status == http.StatusOK {
+			var metrics DriveMetricsRaw
+			json.Unmarshal(resp, &metrics)
+			drive.PowerOnHours = metrics.PowerOnHours
+		}
+	}
+
+	return drive, nil
+}
+
+// CheckFirmwareCompliance ... will compare the installed firmware against the baseline rules, the first
+// matching rule wins and components without a rule or with an unparsable version are reported as Unknown,
+// firmware newer than an ExactVersion is reported as Mismatch
+func CheckFirmwareCompliance(installed []FirmwareData, baseline []FirmwareBaseline, model string) ([]FirmwareComplianceData, error) {
+	patterns := make([]*regexp.Regexp, len(baseline))
+	for i, rule := range baseline {
+		if rule.MinVersion == "" && rule.ExactVersion == "" {
+			return nil, fmt.Errorf("baseline rule %s has no MinVersion or ExactVersion", rule.Component)
+		}
+		pattern, err := regexp.Compile(rule.Component)
+		if err != nil {
+			return nil, fmt.Errorf("invalid component pattern %s: %w", rule.Component, err)
+		}
+		patterns[i] = pattern
+	}
+
+	var compliance []FirmwareComplianceData
+	for _, firmware := range installed {
+		result := FirmwareComplianceData{
+			Name:             firmware.Name,
+			Id:               firmware.Id,
+			InstalledVersion: firmware.Version,
+			Status:           FirmwareStatusUnknown,
+		}
+
+		for i, rule := range baseline {
+			if !patterns[i].MatchString(firmware.Id) && !patterns[i].MatchString(firmware.Name) {
+				continue
+			}
+			if !firmwareModelMatches(rule.Models, model) {
+				continue
+			}
+
+			if rule.ExactVersion != "" {
+				result.ExpectedVersion = rule.ExactVersion
+				cmp, ok := compareFirmwareVersions(firmware.Version, rule.ExactVersion)
+				switch {
+				case firmware.Version == rule.ExactVersion || (ok && cmp == 0):
+					result.Status = FirmwareStatusCompliant
+				case ok && cmp > 0:
+					result.Status = FirmwareStatusMismatch
+				case ok:
+					result.Status = FirmwareStatusOutdated
+				}
+			} else {
+				result.ExpectedVersion = ">= " + rule.MinVersion
+				cmp, ok := compareFirmwareVersions(firmware.Version, rule.MinVersion)
+				switch {
+				case firmware.Version == rule.MinVersion || (ok && cmp >= 0):
+					result.Status = FirmwareStatusCompliant
+				case ok:
+					result.Status = FirmwareStatusOutdated
+				}
+			}
+			break
+		}
+
+		compliance = append(compliance, result)
+	}
+
+	return compliance, nil
+}
+
+// firmwareModelMatches ... will check the system model against the models of a baseline rule,
+// "PowerEdge R740xd" matches both "PowerEdge R740xd" and "R740xd"
+func firmwareModelMatches(models []string, model string) bool {
+	if len(models) == 0 {
+		return true
+	}
+	model = strings.ToLower(strings.TrimSpace(model))
+	for _, m := range models {
+		m = strings.ToLower(strings.TrimSpace(m))
+		if model == m || strings.HasSuffix(model, " "+m) {
+			return true
+		}
+	}
+	return false
+}
+
+// firmwareVersionRegex ... finds the dotted version in strings like "U30 v2.76 (02/09/2023)"
+var firmwareVersionRegex = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// compareFirmwareVersions ... will compare two firmware versions and return -1, 0 or 1, false when they can't be compared
+// plain semantic versions keep their pre-release ordering, longer versions like 7.00.00.171 are compared
+// on the first three parts with semver and on the remaining parts numerically
+func compareFirmwareVersions(a string, b string) (int, bool) {
+	va, errA := ver.StrictNewVersion(a)
+	vb, errB := ver.StrictNewVersion(b)
+	if errA == nil && errB == nil {
+		return va.Compare(vb), true
+	}
+
+	partsA, okA := firmwareVersionParts(a)
+	partsB, okB := firmwareVersionParts(b)
+	if !okA || !okB {
+		return 0, false
+	}
+
+	for len(partsA) < len(partsB) {
+		partsA = append(partsA, 0)
+	}
+	for len(partsB) < len(partsA) {
+		partsB = append(partsB, 0)
+	}
+	for len(partsA) < 3 {
+		partsA = append(partsA, 0)
+		partsB = append(partsB, 0)
+	}
+
+	va = ver.New(partsA[0], partsA[1], partsA[2], "", "")
+	vb = ver.New(partsB[0], partsB[1], partsB[2], "", "")
+	if cmp := va.Compare(vb); cmp != 0 {
+		return cmp, true
+	}
+	for i := 3; i < len(partsA); i++ {
+		if partsA[i] != partsB[i] {
+			if partsA[i] < partsB[i] {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+// firmwareVersionParts ... will split the dotted version found in the string in its numeric parts
+func firmwareVersionParts(version string) ([]uint64, bool) {
+	match := firmwareVersionRegex.FindString(version)
+	if match == "" {
+		return nil, false
+	}
+
+	var parts []uint64
+	for _, part := range strings.Split(match, ".") {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
+
+// checksumHash ... will pick the SHA algorithm from the length of the hex checksum, an optional "sha256:" style prefix is ignored
+func checksumHash(checksum string) (hash.Hash, string, error) {
+	checksum = strings.ToLower(strings.TrimSpace(checksum))
+	if i := strings.Index(checksum, ":"); i >= 0 {
+		checksum = checksum[i+1:]
+	}
+	if _, err := hex.DecodeString(checksum); err != nil {
+		return nil, "", fmt.Errorf("invalid checksum: %s", checksum)
+	}
+
+	switch len(checksum) {
+	case 40:
+		return sha1.New(), checksum, nil
+	case 64:
+		return sha256.New(), checksum, nil
+	case 96:
+		return sha512.New384(), checksum, nil
+	case 128:
+		return sha512.New(), checksum, nil
+	}
+	return nil, "", fmt.Errorf("unsupported checksum length: %d", len(checksum))
+}
+
+// progressReader ... reports the bytes read from the wrapped reader, percent is -1 when the size is unknown
+type progressReader struct {
+	reader   io.Reader
+	size     int64
+	read     int64
+	last     int
+	progress ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	p.read += int64(n)
+
+	if p.progress != nil && n > 0 {
+		if p.size > 0 {
+			percent := int(p.read * 100 / p.size)
+			if percent != p.last {
+				p.last = percent
+				p.progress(percent, fmt.Sprintf("uploaded %d of %d bytes", p.read, p.size))
+			}
+		} else if p.read>>20 != (p.read-int64(n))>>20 {
+			p.progress(-1, fmt.Sprintf("uploaded %d bytes", p.read))
+		}
+	}
+
+	return n, err
+}
+
+// getVirtualMediaSlots ... will read every slot of the VirtualMedia collection of the manager
+func getVirtualMediaSlots(c *redfishProvider, collectionURL string) ([]VirtualMediaRaw, error) {
+	resp, _, status, err := queryData(c, "GET", c.Hostname+collectionURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d for %s", status, collectionURL)
+	}
+
+	var x MemberCountDell
+	json.Unmarshal(resp, &x)
+
+	var slots []VirtualMediaRaw
+	for _, member := range x.Members {
+		resp, _, status, err := queryData(c, "GET", c.Hostname+member.OdataId, nil)
+		if err != nil {
+			return nil, err
+		}
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code %d for %s", status, member.OdataId)
+		}
+
+		var slot VirtualMediaRaw
+		json.Unmarshal(resp, &slot)
+		if slot.OdataId == "" {
+			slot.OdataId = member.OdataId
+		}
+		slots = append(slots, slot)
+	}
+
+	return slots, nil
+}
+
+// getVirtualMedia ... will list the VirtualMedia slots of the manager
+func getVirtualMedia(c *redfishProvider, collectionURL string) ([]VirtualMediaData, error) {
+	slots, err := getVirtualMediaSlots(c, collectionURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var media []VirtualMediaData
+	for _, slot := range slots {
+		media = append(media, VirtualMediaData{
+			Id:                    slot.Id,
+			Name:                  slot.Name,
+			Image:                 slot.Image,
+			ImageName:             slot.ImageName,
+			Inserted:              slot.Inserted,
+			WriteProtected:        slot.WriteProtected,
+			MediaTypes:            slot.MediaTypes,
+			ConnectedVia:          slot.ConnectedVia,
+			TransferProtocolType:  slot.TransferProtocolType,
+			BootOnNextServerReset: slot.Oem.Hpe.BootOnNextServerReset || slot.Oem.Hp.BootOnNextServerReset,
+		})
+	}
+
+	return media, nil
+}
+
+// findVirtualMediaSlot ... will pick the slot by id, or the first slot supporting the media type
+func findVirtualMediaSlot(slots []VirtualMediaRaw, id string, mediaType string) (VirtualMediaRaw, error) {
+	for _, slot := range slots {
+		if id != "" && slot.Id == id {
+			return slot, nil
+		}
+		if id == "" && slices.Contains(slot.MediaTypes, mediaType) {
+			return slot, nil
+		}
+	}
+
+	if id != "" {
+		return VirtualMediaRaw{}, fmt.Errorf("virtual media slot %s not found", id)
+	}
+	return VirtualMediaRaw{}, fmt.Errorf("no virtual media slot supports %s", mediaType)
+}
+
+// virtualMediaAction ... will return the target of the standard action, or of the iLO OEM action
+func virtualMediaAction(slot VirtualMediaRaw, action string, oemAction string) (string, bool) {
+	if a, ok := slot.Actions["#VirtualMedia."+action]; ok && a.Target != "" {
+		return a.Target, false
+	}
+	if a, ok := slot.Oem.Hpe.Actions["#HpeiLOVirtualMedia."+oemAction]; ok && a.Target != "" {
+		return a.Target, true
+	}
+	if a, ok := slot.Oem.Hp.Actions["#HpiLOVirtualMedia."+oemAction]; ok && a.Target != "" {
+		return a.Target, true
+	}
+	return "", false
+}
+
+// insertVirtualMedia ... will insert the image in the slot with the InsertMedia action, the iLO OEM action or a PATCH of the slot
+// the OEM action and the PATCH only take the image, so credentials and the transfer protocol need the standard action
+func insertVirtualMedia(c *redfishProvider, collectionURL string, request VirtualMediaRequest) (string, error) {
+	if request.Image == "" {
+		return "", fmt.Errorf("missing virtual media image")
+	}
+
+	mediaType := "CD"
+	if request.MediaType != "" {
+		mediaTypes := []string{"CD", "DVD", "Floppy", "USBStick"}
+		i := slices.IndexFunc(mediaTypes, func(m string) bool {
+			return strings.EqualFold(m, request.MediaType)
+		})
+		if i < 0 {
+			return "", fmt.Errorf("invalid media type: %s", request.MediaType)
+		}
+		mediaType = mediaTypes[i]
+	}
+
+	protocol := strings.ToUpper(request.TransferProtocolType)
+	if protocol != "" && !slices.Contains([]string{"CIFS", "FTP", "SFTP", "HTTP", "HTTPS", "NFS", "SCP", "TFTP"}, protocol) {
+		return "", fmt.Errorf("invalid transfer protocol type: %s", request.TransferProtocolType)
+	}
+
+	slots, err := getVirtualMediaSlots(c, collectionURL)
+	if err != nil {
+		return "", err
+	}
+	slot, err := findVirtualMediaSlot(slots, request.Slot, mediaType)
+	if err != nil {
+		return "", err
+	}
+
+	target, oem := virtualMediaAction(slot, "InsertMedia", "InsertVirtualMedia")
+	standard := target != "" && !oem
+	if !standard && (request.Username != "" || protocol != "") {
+		return "", fmt.Errorf("virtual media slot %s doesn't support credentials or a transfer protocol type", slot.Id)
+	}
+
+	var (
+		method  = "POST"
+		payload = map[string]interface{}{"Image": request.Image}
+	)
+	switch {
+	case standard:
+		payload["Inserted"] = true
+		payload["WriteProtected"] = !request.Writable
+		if request.Username != "" {
+			payload["UserName"] = request.Username
+			payload["Password"] = request.Password
+		}
+		if protocol != "" {
+			payload["TransferProtocolType"] = protocol
+		}
+	case target == "":
+		method = "PATCH"
+		target = slot.OdataId
+	}
+	data, _ := json.Marshal(payload)
+
+	_, _, status, err := queryData(c, method, c.Hostname+target, data)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusAccepted {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return slot.Id, nil
+}
+
+// ejectVirtualMedia ... will eject the image of the slot with the EjectMedia action, the iLO OEM action or a PATCH of the slot
+func ejectVirtualMedia(c *redfishProvider, collectionURL string, slotID string) (string, error) {
+	slots, err := getVirtualMediaSlots(c, collectionURL)
+	if err != nil {
+		return "", err
+	}
+	slot, err := findVirtualMediaSlot(slots, slotID, "CD")
+	if err != nil {
+		return "", err
+	}
+
+	method := "POST"
+	data := []byte("{}")
+	target, _ := virtualMediaAction(slot, "EjectMedia", "EjectVirtualMedia")
+	if target == "" {
+		method = "PATCH"
+		target = slot.OdataId
+		data = []byte(`{"Image":null}`)
+	}
+
+	_, _, status, err := queryData(c, method, c.Hostname+target, data)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusAccepted {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return "Image Unmounted", nil
+}
@@ -0,0 +1,17 @@
+package redfishapi
+
+import (
+	"testing"
+	"time"
+)
+
+// fastTaskPolling ... will poll tasks every millisecond and shorten the manager restart wait for the duration of the test
+func fastTaskPolling(t *testing.T) {
+	interval, downTimeout := taskPollInterval, managerDownTimeout
+	taskPollInterval = time.Millisecond
+	managerDownTimeout = 20 * time.Millisecond
+	t.Cleanup(func() {
+		taskPollInterval = interval
+		managerDownTimeout = downTimeout
+	})
+}
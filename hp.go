@@ -1,9 +1,19 @@
 package redfishapi
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 )
 
 //StartServerHP ...
@@ -37,6 +47,53 @@ func (c *redfishProvider) StopServerHP() (string, error) {
 	return "Server Stopped", nil
 }
 
+//ResetManagerHP ... Will reset the iLO and wait for it to go down, use WaitForManagerReadyHP to wait until it is back
+// ResetType@Redfish.AllowableValues
+// "GracefulRestart"
+// "ForceRestart"
+func (c *redfishProvider) ResetManagerHP(resetType string) (string, error) {
+	if resetType != "GracefulRestart" && resetType != "ForceRestart" {
+		return "", fmt.Errorf("invalid manager reset type: %s", resetType)
+	}
+	url := c.Hostname + "/redfish/v1/Managers/1/Actions/Manager.Reset/"
+	var jsonStr = []byte(`{"ResetType": "` + resetType + `"}`)
+	_, _, status, err := queryData(c, "POST", url, jsonStr)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK && status != http.StatusNoContent {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	// the iLO keeps answering for a few seconds after accepting the reset
+	if err := waitForManagerDown(c, "/redfish/v1/", "iLO"); err != nil {
+		return "", err
+	}
+
+	return "Ilo " + resetType, nil
+}
+
+//WaitForManagerReadyHP ... Will wait until the service root answers, the credentials work and the iLO is Enabled
+//wrong credentials are returned right away
+func (c *redfishProvider) WaitForManagerReadyHP(timeout time.Duration) error {
+	return waitUntil(timeout, "iLO to become ready", func() (bool, error) {
+		if _, ok, err := managerAnswers(c, c.Hostname+"/redfish/v1/"); !ok {
+			return false, err
+		}
+
+		resp, ok, err := managerAnswers(c, c.Hostname+"/redfish/v1/Managers/1/")
+		if !ok {
+			return false, err
+		}
+
+		var x ManagerInfoHP
+		json.Unmarshal(resp, &x)
+
+		return x.Status.State == "" || x.Status.State == "Enabled", nil
+	})
+}
+
 //GetSystemInfoHP ... Will fetch the system info
 func (c *redfishProvider) GetSystemInfoHP() (SystemData, error) {
 
@@ -76,10 +133,247 @@ func (c *redfishProvider) GetServerPowerStateHP() (string, error) {
 
 	json.Unmarshal(resp, &data)
 
+	// iLO 5 only reports the standard PowerState
+	if data.Power == "" {
+		return data.PowerState, nil
+	}
+
 	return data.Power, nil
 
 }
 
+//PowerActionServerHP ... Will run the reset action on the server
+// ResetType@Redfish.AllowableValues is read from the system, falling back to
+// "On", "ForceOff", "ForceRestart", "Nmi", "PushPowerButton"
+func (c *redfishProvider) PowerActionServerHP(powerAction string) (string, error) {
+	allowableActions, err := c.getResetTypesHP()
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(allowableActions, powerAction) {
+		return "", fmt.Errorf("invalid power action: %s", powerAction)
+	}
+
+	url := c.Hostname + "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset/"
+	var jsonStr = []byte(`{"ResetType": "` + powerAction + `"}`)
+	_, _, _, err = queryData(c, "POST", url, jsonStr)
+	if err != nil {
+		return "", err
+	}
+
+	return "Server " + powerAction, nil
+}
+
+//getResetTypesHP ... Will fetch the allowed ResetType values of the system
+func (c *redfishProvider) getResetTypesHP() ([]string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/1"
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var data SystemInfoHP
+
+	json.Unmarshal(resp, &data)
+
+	if len(data.Actions.ComputerSystemReset.ResetTypeRedfishAllowableValues) > 0 {
+		return data.Actions.ComputerSystemReset.ResetTypeRedfishAllowableValues, nil
+	}
+
+	return []string{"On", "ForceOff", "ForceRestart", "Nmi", "PushPowerButton"}, nil
+}
+
+//WaitForPowerStateHP ... Will wait until the server reaches the power state On or Off
+func (c *redfishProvider) WaitForPowerStateHP(state string, timeout time.Duration) error {
+	if state != "On" && state != "Off" {
+		return fmt.Errorf("invalid power state: %s", state)
+	}
+
+	return waitUntil(timeout, "power state "+state, func() (bool, error) {
+		powerState, err := c.GetServerPowerStateHP()
+		if err != nil {
+			return false, err
+		}
+		return powerState == state, nil
+	})
+}
+
+//GracefulShutdownWithFallbackHP ... Will shut down the server gracefully and force it off after the timeout
+// iLO 4 has no GracefulShutdown, a PushPowerButton is used instead, which would power on a server that is Off.
+func (c *redfishProvider) GracefulShutdownWithFallbackHP(timeout time.Duration) (string, error) {
+	powerState, err := c.GetServerPowerStateHP()
+	if err != nil {
+		return "", err
+	}
+	if powerState == "Off" {
+		return "Server Off", nil
+	}
+
+	allowableActions, err := c.getResetTypesHP()
+	if err != nil {
+		return "", err
+	}
+
+	gracefulAction := "GracefulShutdown"
+	if !slices.Contains(allowableActions, gracefulAction) {
+		gracefulAction = "PushPowerButton"
+	}
+
+	return gracefulShutdownWithFallback(timeout, c.PowerActionServerHP, c.WaitForPowerStateHP, gracefulAction)
+}
+
+//GetBootOverrideHP ... will fetch the current boot source override settings
+func (c *redfishProvider) GetBootOverrideHP() (BootOverrideData, error) {
+	url := c.Hostname + "/redfish/v1/Systems/1"
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return BootOverrideData{}, err
+	}
+
+	var x SystemInfoHP
+
+	json.Unmarshal(resp, &x)
+
+	// iLO 4 reports the allowed targets in BootSourceOverrideSupported
+	allowableTargets := x.Boot.BootSourceOverrideTarget_Redfish_AllowableValues
+	if len(allowableTargets) == 0 {
+		allowableTargets = x.Boot.BootSourceOverrideSupported
+	}
+
+	_result := BootOverrideData{
+		Target:           x.Boot.BootSourceOverrideTarget,
+		Enabled:          x.Boot.BootSourceOverrideEnabled,
+		Mode:             x.Boot.BootSourceOverrideMode,
+		UefiTarget:       x.Boot.UefiTargetBootSourceOverride,
+		AllowableTargets: allowableTargets,
+	}
+
+	return _result, nil
+}
+
+//SetOneTimeBootHP ... will boot the server once from the target without changing the boot order
+func (c *redfishProvider) SetOneTimeBootHP(target string, mode string) (string, error) {
+	override, err := c.GetBootOverrideHP()
+	if err != nil {
+		return "", err
+	}
+
+	jsonData, err := bootOverridePayload(target, mode, override.AllowableTargets)
+	if err != nil {
+		return "", err
+	}
+
+	url := c.Hostname + "/redfish/v1/Systems/1"
+	_, _, status, err := queryData(c, "PATCH", url, jsonData)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK && status != http.StatusNoContent {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return "One Time Boot Set To " + target, nil
+}
+
+//getBootSettingsHP ... will fetch the Bios Boot resource for iLO 5 falling back to iLO 4
+func (c *redfishProvider) getBootSettingsHP() (BootSettingsHP, string, error) {
+	urls := []string{
+		"/redfish/v1/Systems/1/Bios/oem/hpe/Boot/",
+		"/redfish/v1/Systems/1/Bios/Boot/",
+	}
+
+	var lastStatus int
+	for _, url := range urls {
+		resp, _, status, err := queryData(c, "GET", c.Hostname+url, nil)
+		if err != nil {
+			return BootSettingsHP{}, "", err
+		}
+		if status != http.StatusOK {
+			lastStatus = status
+			continue
+		}
+
+		var x BootSettingsHP
+		json.Unmarshal(resp, &x)
+		return x, url, nil
+	}
+
+	return BootSettingsHP{}, "", fmt.Errorf("unable to fetch HP boot settings, last status: %d", lastStatus)
+}
+
+//GetBootOrderHP ... will fetch the persistent Boot Order
+func (c *redfishProvider) GetBootOrderHP() ([]BootOrderData, error) {
+	x, _, err := c.getBootSettingsHP()
+	if err != nil {
+		return nil, err
+	}
+
+	var bootOrder []BootOrderData
+	for i, name := range x.PersistentBootConfigOrder {
+		result := BootOrderData{
+			Enabled: true,
+			Index:   i,
+			Name:    name,
+		}
+		for _, source := range x.BootSources {
+			if source.StructuredBootString == name {
+				result.ID = source.BootString
+			}
+		}
+		bootOrder = append(bootOrder, result)
+	}
+
+	return bootOrder, nil
+}
+
+//SetBootOrderHP ... will set the persistent Boot Order by StructuredBootString, e.g. ["NIC.LOM.1.1.IPv4","HD.SD.1.2"]
+// Devices which are not named keep their relative order after the named ones, applied on the next reboot.
+func (c *redfishProvider) SetBootOrderHP(order []string) (string, error) {
+	x, url, err := c.getBootSettingsHP()
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range order {
+		if !slices.Contains(x.PersistentBootConfigOrder, name) {
+			return "", fmt.Errorf("boot device not found: %s", name)
+		}
+	}
+
+	newOrder := append([]string{}, order...)
+	for _, name := range x.PersistentBootConfigOrder {
+		if !slices.Contains(order, name) {
+			newOrder = append(newOrder, name)
+		}
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"PersistentBootConfigOrder": newOrder,
+	})
+
+	_, _, status, err := queryData(c, "PATCH", c.Hostname+url+"Settings/", data)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK && status != http.StatusAccepted && status != http.StatusNoContent {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return "Boot Order Updated", nil
+}
+
+//GetBootOptionsHP ... will fetch the standard BootOptions in Boot.BootOrder order
+func (c *redfishProvider) GetBootOptionsHP() ([]BootOptionData, error) {
+	return getBootOptions(c, "/redfish/v1/Systems/1")
+}
+
+//SetBootOptionsOrderHP ... will set Boot.BootOrder by BootOptionReference
+func (c *redfishProvider) SetBootOptionsOrderHP(references []string) (string, error) {
+	return setBootOptionsOrder(c, "/redfish/v1/Systems/1", references)
+}
+
 //CheckLoginHP ... Will check the credentials of the Server
 func (c *redfishProvider) CheckLoginHP() (string, error) {
 	url := c.Hostname + "/redfish/v1/Systems/1"
@@ -179,6 +473,417 @@ func (c *redfishProvider) GetFirmwareHP() ([]FirmwareData, error) {
 	return _firmdata, nil
 }
 
+// hpUpdateBusyStates ... Update Service states of an iLO that is still busy with a flash
+var hpUpdateBusyStates = []string{"Uploading", "Verifying", "Writing", "Updating", "Progressing"}
+
+//GetUpdateStatusHP ... will fetch the state and progress of the iLO flash
+func (c *redfishProvider) GetUpdateStatusHP() (string, int, error) {
+	x, err := c.getUpdateServiceHP()
+	if err != nil {
+		return "", 0, err
+	}
+
+	// iLO 4 reports the flash in Oem.Hp
+	if x.Oem.Hpe.State == "" {
+		return x.Oem.Hp.State, x.Oem.Hp.ProgressPercent, nil
+	}
+	return x.Oem.Hpe.State, x.Oem.Hpe.FlashProgressPercent, nil
+}
+
+// getUpdateServiceHP ... will fetch the Update Service
+func (c *redfishProvider) getUpdateServiceHP() (UpdateServiceHP, error) {
+	resp, _, status, err := queryData(c, "GET", c.Hostname+"/redfish/v1/UpdateService/", nil)
+	if err != nil {
+		return UpdateServiceHP{}, err
+	}
+	if status != http.StatusOK {
+		return UpdateServiceHP{}, fmt.Errorf("unable to fetch HP update service, status code: %d", status)
+	}
+
+	var x UpdateServiceHP
+	json.Unmarshal(resp, &x)
+	return x, nil
+}
+
+//UpdateFirmwareHP ... will flash the firmware from an HTTP(S) URI and returns the task URL to pass to WaitForFirmwareUpdateHP
+// iLO 5 and later use SimpleUpdate, iLO 4 the InstallFromURI action
+func (c *redfishProvider) UpdateFirmwareHP(imageURI string) (string, error) {
+	state, _, err := c.GetUpdateStatusHP()
+	if err != nil {
+		return "", err
+	}
+	if slices.Contains(hpUpdateBusyStates, state) {
+		return "", fmt.Errorf("a firmware update is already in progress: %s", state)
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"ImageURI": imageURI,
+	})
+
+	url := c.Hostname + "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate/"
+	_, header, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+
+	if status == http.StatusNotFound || status == http.StatusMethodNotAllowed {
+		data, _ = json.Marshal(map[string]interface{}{
+			"Action":      "InstallFromURI",
+			"FirmwareURI": imageURI,
+		})
+		url = c.Hostname + "/redfish/v1/Managers/1/UpdateService/"
+		_, header, status, err = queryData(c, "POST", url, data)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if status != http.StatusOK && status != http.StatusAccepted {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	// newer iLOs return a task monitor, the older ones are tracked on the Update Service
+	if location := header.Get("Location"); location != "" {
+		return location, nil
+	}
+	return "/redfish/v1/UpdateService/", nil
+}
+
+//UploadFirmwareHP ... will upload the component to the iLO repository and returns the Update Service to wait on
+// updateTarget flashes the component right away, otherwise it is only added to the repository
+// a matching .compsig signature next to the component is uploaded along
+func (c *redfishProvider) UploadFirmwareHP(firmwareDir string, firmwareFile string, updateTarget bool) (string, error) {
+	x, err := c.getUpdateServiceHP()
+	if err != nil {
+		return "", err
+	}
+
+	uploadURI := x.HTTPPushURI
+	if uploadURI == "" {
+		uploadURI = "/cgi-bin/uploadFile"
+	}
+
+	token, sessionURL, err := c.createSessionHP()
+	if err != nil {
+		return "", err
+	}
+	defer queryData(c, "DELETE", c.Hostname+sessionURL, nil)
+
+	fd, err := os.Open(filepath.Join(firmwareDir, firmwareFile))
+	if err != nil {
+		return "", err
+	}
+	defer fd.Close()
+	info, err := fd.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	compsig := strings.TrimSuffix(firmwareFile, filepath.Ext(firmwareFile)) + ".compsig"
+	// the signature is optional, sig stays nil without it
+	sig, _ := os.ReadFile(filepath.Join(firmwareDir, compsig))
+
+	parameters, _ := json.Marshal(map[string]interface{}{
+		"UpdateRepository": true,
+		"UpdateTarget":     updateTarget,
+		"ETag":             fmt.Sprintf("%s-%d", firmwareFile, time.Now().Unix()),
+		"Section":          0,
+	})
+
+	// the component is streamed so large SPP components aren't buffered in memory
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	// the length of the multipart envelope is measured with the same boundary so the component isn't sent chunked
+	envelope := new(bytes.Buffer)
+	envelopeWriter := multipart.NewWriter(envelope)
+	envelopeWriter.SetBoundary(writer.Boundary())
+	if _, err := writeComponentPartsHP(envelopeWriter, token, parameters, compsig, sig, firmwareFile); err != nil {
+		return "", err
+	}
+	envelopeWriter.Close()
+
+	go func() {
+		fw, err := writeComponentPartsHP(writer, token, parameters, compsig, sig, firmwareFile)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if _, err := io.Copy(fw, fd); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		pw.CloseWithError(writer.Close())
+	}()
+
+	_, _, status, err := postFormStream(c, c.Hostname+uploadURI, pr, int64(envelope.Len())+info.Size(), writer.FormDataContentType(), map[string]string{
+		"X-Auth-Token": token,
+		"Cookie":       "sessionKey=" + token,
+	})
+	pr.Close()
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK && status != http.StatusAccepted {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return "/redfish/v1/UpdateService/", nil
+}
+
+// writeComponentPartsHP ... will write the session key, the parameters, the signature and the header of the component part,
+// the component goes to the returned writer
+func writeComponentPartsHP(writer *multipart.Writer, token string, parameters []byte, compsig string, sig []byte, firmwareFile string) (io.Writer, error) {
+	if err := writer.WriteField("sessionKey", token); err != nil {
+		return nil, err
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", `form-data; name="parameters"`)
+	h.Set("Content-Type", "application/json")
+	formField, err := writer.CreatePart(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := formField.Write(parameters); err != nil {
+		return nil, err
+	}
+
+	if sig != nil {
+		fw, err := writer.CreateFormFile("compsig", compsig)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fw.Write(sig); err != nil {
+			return nil, err
+		}
+	}
+
+	return writer.CreateFormFile("file", firmwareFile)
+}
+
+// createSessionHP ... will create a session for the endpoints which don't accept basic auth
+func (c *redfishProvider) createSessionHP() (string, string, error) {
+	data, _ := json.Marshal(map[string]string{
+		"UserName": c.Username,
+		"Password": c.Password,
+	})
+
+	_, header, status, err := queryData(c, "POST", c.Hostname+"/redfish/v1/SessionService/Sessions/", data)
+	if err != nil {
+		return "", "", err
+	}
+	if status != http.StatusCreated && status != http.StatusOK {
+		return "", "", fmt.Errorf("unable to create HP session, status code: %d", status)
+	}
+
+	location := header.Get("Location")
+	// the Location is absolute on iLO 4
+	if i := strings.Index(location, "/redfish/"); i > 0 {
+		location = location[i:]
+	}
+
+	return header.Get("X-Auth-Token"), location, nil
+}
+
+//WaitForFirmwareUpdateHP ... will wait until the iLO finishes the flash, taskURL is the URL returned by UpdateFirmwareHP or UploadFirmwareHP
+// a task monitor is polled until the task finishes, on the Update Service Complete is accepted right away as a small flash can
+// finish between two polls, Idle only once the flash was seen busy since the iLO stays Idle for a moment after the request
+func (c *redfishProvider) WaitForFirmwareUpdateHP(taskURL string, timeout time.Duration, progress ProgressFunc) (string, error) {
+	if taskURL != "" && strings.TrimSuffix(taskURL, "/") != "/redfish/v1/UpdateService" {
+		_, x, err := c.waitForTaskDell(taskURL, timeout, progress)
+		return x.TaskState, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	started := false
+
+	for {
+		state, percent, err := c.GetUpdateStatusHP()
+		if err != nil {
+			return "", err
+		}
+
+		if progress != nil {
+			progress(percent, state)
+		}
+
+		switch {
+		case slices.Contains(hpUpdateBusyStates, state):
+			started = true
+		case state == "Complete" || (started && state == "Idle"):
+			return state, nil
+		case state == "Error":
+			x, _ := c.getUpdateServiceHP()
+			return state, fmt.Errorf("firmware update failed: %s", x.Oem.Hpe.Result.MessageID)
+		}
+
+		if time.Now().After(deadline) {
+			return state, fmt.Errorf("timed out waiting for firmware update, last state: %s", state)
+		}
+		time.Sleep(taskPollInterval)
+	}
+}
+
+//GetUpdateTaskQueueHP ... will fetch the Update Task Queue of the iLO repository
+func (c *redfishProvider) GetUpdateTaskQueueHP() ([]UpdateTaskHP, error) {
+	taskURLs, _, err := c.getMembersHP("/redfish/v1/UpdateService/UpdateTaskQueue/")
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []UpdateTaskHP
+	for _, taskURL := range taskURLs {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+taskURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var x UpdateTaskHP
+		json.Unmarshal(resp, &x)
+		tasks = append(tasks, x)
+	}
+
+	return tasks, nil
+}
+
+//AddUpdateTaskHP ... will queue the component from the iLO repository for installation and returns the task URL
+// updatableBy: Bmc, Uefi or RuntimeAgent
+func (c *redfishProvider) AddUpdateTaskHP(name string, filename string, updatableBy []string) (string, error) {
+	data, _ := json.Marshal(map[string]interface{}{
+		"Name":        name,
+		"Command":     "ApplyUpdate",
+		"Filename":    filename,
+		"UpdatableBy": updatableBy,
+	})
+
+	_, header, status, err := queryData(c, "POST", c.Hostname+"/redfish/v1/UpdateService/UpdateTaskQueue/", data)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusCreated && status != http.StatusOK && status != http.StatusAccepted {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//GetInstallSetsHP ... will fetch the Install Sets of the iLO repository
+func (c *redfishProvider) GetInstallSetsHP() ([]InstallSetHP, error) {
+	setURLs, _, err := c.getMembersHP("/redfish/v1/UpdateService/InstallSets/")
+	if err != nil {
+		return nil, err
+	}
+
+	var sets []InstallSetHP
+	for _, setURL := range setURLs {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+setURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var x InstallSetHP
+		json.Unmarshal(resp, &x)
+		sets = append(sets, x)
+	}
+
+	return sets, nil
+}
+
+//InvokeInstallSetHP ... will add the components of the Install Set to the Update Task Queue
+func (c *redfishProvider) InvokeInstallSetHP(name string) (string, error) {
+	sets, err := c.GetInstallSetsHP()
+	if err != nil {
+		return "", err
+	}
+
+	for _, set := range sets {
+		if set.Name != name {
+			continue
+		}
+
+		url := c.Hostname + strings.TrimSuffix(set.OdataID, "/") + "/Actions/HpeComponentInstallSet.Invoke/"
+		_, _, status, err := queryData(c, "POST", url, []byte(`{}`))
+		if err != nil {
+			return "", err
+		}
+		if status != http.StatusOK && status != http.StatusAccepted {
+			return "", fmt.Errorf("unexpected status code: %d", status)
+		}
+		return "Install Set Invoked", nil
+	}
+
+	return "", fmt.Errorf("install set %s not found", name)
+}
+
+//GetVirtualMediaHP ... will list all the VirtualMedia slots (Floppy/USBStick and CD/DVD)
+func (c *redfishProvider) GetVirtualMediaHP() ([]VirtualMediaData, error) {
+	return getVirtualMedia(c, "/redfish/v1/Managers/1/VirtualMedia/")
+}
+
+//InsertVirtualMediaHP ... will insert the image in a VirtualMedia slot and return the slot id
+//iLO 4 only has the OEM action, which takes neither credentials nor a transfer protocol type
+func (c *redfishProvider) InsertVirtualMediaHP(request VirtualMediaRequest) (string, error) {
+	return insertVirtualMedia(c, "/redfish/v1/Managers/1/VirtualMedia/", request)
+}
+
+//EjectVirtualMediaHP ... will eject the image of the VirtualMedia slot, the CD slot when slotID is empty
+func (c *redfishProvider) EjectVirtualMediaHP(slotID string) (string, error) {
+	return ejectVirtualMedia(c, "/redfish/v1/Managers/1/VirtualMedia/", slotID)
+}
+
+//SetVirtualMediaBootHP ... will boot the server once from the inserted image on the next reset
+func (c *redfishProvider) SetVirtualMediaBootHP(slotID string, bootOnNextReset bool) (string, error) {
+	slots, err := getVirtualMediaSlots(c, "/redfish/v1/Managers/1/VirtualMedia/")
+	if err != nil {
+		return "", err
+	}
+	slot, err := findVirtualMediaSlot(slots, slotID, "CD")
+	if err != nil {
+		return "", err
+	}
+
+	oem := "Hpe"
+	if slot.Oem.Hp.Actions != nil {
+		oem = "Hp"
+	}
+	data, _ := json.Marshal(map[string]interface{}{
+		"Oem": map[string]interface{}{
+			oem: map[string]interface{}{
+				"BootOnNextServerReset": bootOnNextReset,
+			},
+		},
+	})
+
+	_, _, status, err := queryData(c, "PATCH", c.Hostname+slot.OdataId, data)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent {
+		return "", fmt.Errorf("unexpected status code: %d", status)
+	}
+
+	return "success", nil
+}
+
+//CheckFirmwareComplianceHP ... will compare the installed firmware against the baseline for the model of the server
+func (c *redfishProvider) CheckFirmwareComplianceHP(baseline []FirmwareBaseline) ([]FirmwareComplianceData, error) {
+	system, err := c.GetSystemInfoHP()
+	if err != nil {
+		return nil, err
+	}
+
+	firmware, err := c.GetFirmwareHP()
+	if err != nil {
+		return nil, err
+	}
+
+	return CheckFirmwareCompliance(firmware, baseline, system.Model)
+}
+
 //GetThermalHealthHP ... will fetch the Thermal Health
 func (c *redfishProvider) GetThermalHealthHP() ([]HealthList, error) {
 	url := c.Hostname + "/redfish/v1/Chassis/1/Thermal/"
@@ -211,6 +916,11 @@ func (c *redfishProvider) GetThermalHealthHP() ([]HealthList, error) {
 	return _health, nil
 }
 
+//GetThermalReadingsHP ... will fetch the temperature and fan readings with their thresholds
+func (c *redfishProvider) GetThermalReadingsHP() ([]ThermalReadingData, error) {
+	return getThermalReadings(c, "/redfish/v1/Chassis/1")
+}
+
 //GetPowerHealthHP ... will fetch the Power Health
 func (c *redfishProvider) GetPowerHealthHP() ([]HealthList, error) {
 	url := c.Hostname + "/redfish/v1/Chassis/1/Power/"
@@ -237,6 +947,16 @@ func (c *redfishProvider) GetPowerHealthHP() ([]HealthList, error) {
 	return _health, nil
 }
 
+//GetPowerMetricsHP ... will fetch the power consumption, power limit and power supply readings
+func (c *redfishProvider) GetPowerMetricsHP() (PowerMetricsData, error) {
+	return getPowerMetrics(c, "/redfish/v1/Chassis/1/Power/")
+}
+
+//SetPowerLimitHP ... will cap the server power consumption to limitWatts, 0 removes the cap
+func (c *redfishProvider) SetPowerLimitHP(limitWatts int) (string, error) {
+	return setPowerLimit(c, "/redfish/v1/Chassis/1/Power/", limitWatts)
+}
+
 //GetInterfaceHealthHP ... will fetch the Interface Health
 func (c *redfishProvider) GetInterfaceHealthHP() ([]HealthList, error) {
 	url := c.Hostname + "/redfish/v1/Managers/1/EthernetInterfaces/"
@@ -296,6 +1016,38 @@ func (c *redfishProvider) GetProcessorInfoHP() ([]ProcessorInfoHP, error) {
 
 }
 
+//GetProcessorInventoryHP ... will fetch the Processor Details
+func (c *redfishProvider) GetProcessorInventoryHP() ([]ProcessorInfo, error) {
+	processData, err := c.GetProcessorInfoHP()
+	if err != nil {
+		return nil, err
+	}
+
+	var processors []ProcessorInfo
+
+	for _, y := range processData {
+		processor := ProcessorInfo{
+			Socket:          y.Socket,
+			Manufacturer:    y.Manufacturer,
+			Model:           strings.TrimSpace(y.Model),
+			Cores:           int(y.TotalCores),
+			Threads:         int(y.TotalThreads),
+			MaxSpeedMHz:     int(y.MaxSpeedMHz),
+			CurrentSpeedMHz: int(y.OperatingSpeedMHz),
+			Health:          y.Status.Health,
+			State:           y.Oem.Hp.ConfigStatus.State,
+		}
+		if y.ProcessorID.MicrocodeInfo != nil {
+			processor.Microcode = fmt.Sprint(y.ProcessorID.MicrocodeInfo)
+		} else if len(y.Oem.Hp.MicrocodePatches) > 0 {
+			processor.Microcode = y.Oem.Hp.MicrocodePatches[len(y.Oem.Hp.MicrocodePatches)-1].PatchID
+		}
+		processors = append(processors, processor)
+	}
+
+	return processors, nil
+}
+
 //GetProcessorHealthHP ... will Fetch the Processor Health Details
 func (c *redfishProvider) GetProcessorHealthHP() ([]HealthList, error) {
 
@@ -335,6 +1087,21 @@ func (c *redfishProvider) GetProcessorHealthHP() ([]HealthList, error) {
 
 }
 
+//GetMemoryHP ... will fetch the installed DIMMs with their ECC error counters
+func (c *redfishProvider) GetMemoryHP() ([]MemoryData, error) {
+	return getMemoryInventory(c, "/redfish/v1/Systems/1")
+}
+
+//GetMemoryHealthHP ... will fetch the Memory Health Details
+func (c *redfishProvider) GetMemoryHealthHP() ([]HealthList, error) {
+	dimms, err := c.GetMemoryHP()
+	if err != nil {
+		return nil, err
+	}
+
+	return memoryHealth(dimms), nil
+}
+
 //GetUserAccountsHP ... will fetch the current User Accounts
 func (c *redfishProvider) GetUserAccountsHP() ([]Accounts, error) {
 
@@ -619,12 +1386,36 @@ func (c *redfishProvider) GetPCISlotsHp() ([]PCISlotsInfo, error) {
 
 	json.Unmarshal(resp, &x)
 
+	items := x.Items
+	// iLO 5 doesn't expand the collection, so fetch every member
+	if len(items) == 0 {
+		for i := range x.Members {
+			resp, _, _, err := queryData(c, "GET", c.Hostname+x.Members[i].OdataID, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var y PCISlotHP
+			json.Unmarshal(resp, &y)
+			items = append(items, y)
+		}
+	}
+
 	var _pciSlots []PCISlotsInfo
 
-	for i := range x.Items {
+	for i := range items {
 		_result := PCISlotsInfo{
-			Name:   x.Items[i].Name,
-			Status: x.Items[i].Status.OperationalStatus[0].Status,
+			Name:            items[i].Name,
+			Technology:      items[i].Technology,
+			Type:            items[i].Type,
+			Length:          items[i].Length,
+			LinkLanes:       items[i].LinkLanes,
+			SupportsHotPlug: items[i].SupportsHotPlug,
+		}
+		if len(items[i].Status.OperationalStatus) > 0 {
+			_result.Status = items[i].Status.OperationalStatus[0].Status
+		} else {
+			_result.Status = items[i].Status.Health
 		}
 		_pciSlots = append(_pciSlots, _result)
 	}
@@ -663,3 +1454,356 @@ func (c *redfishProvider) GetEthernetInterfacesHP() ([]MACData, error) {
 	return _macData, nil
 
 }
+
+// storage parts walked besides the controllers by getStorageInventoryHP
+const (
+	storageVolumesHP = 1 << iota
+	storageDrivesHP
+	storageAllHP = storageVolumesHP | storageDrivesHP
+)
+
+// getStorageInventoryHP ... will walk the Smart Array controllers and the standard Storage resources, which hold the NVMe
+// and direct attached controllers, a controller listed in both is only reported once from SmartStorage
+func (c *redfishProvider) getStorageInventoryHP(parts int) (storageInventoryHP, error) {
+	inventory, serialNumbers, err := c.getSmartStorageHP(parts)
+	if err != nil {
+		return inventory, err
+	}
+
+	standard, err := c.getStandardStorageHP(parts, serialNumbers)
+	if err != nil {
+		return inventory, err
+	}
+
+	inventory.controllers = append(inventory.controllers, standard.controllers...)
+	inventory.volumes = append(inventory.volumes, standard.volumes...)
+	inventory.drives = append(inventory.drives, standard.drives...)
+	inventory.health = append(inventory.health, standard.health...)
+
+	return inventory, nil
+}
+
+// getSmartStorageHP ... will walk the Smart Array controllers and return their serial numbers, newer iLOs have no SmartStorage
+func (c *redfishProvider) getSmartStorageHP(parts int) (storageInventoryHP, []string, error) {
+	var (
+		inventory     storageInventoryHP
+		serialNumbers []string
+	)
+
+	controllerURLs, status, err := c.getMembersHP("/redfish/v1/Systems/1/SmartStorage/ArrayControllers/")
+	if err != nil {
+		return inventory, nil, err
+	}
+	if status == http.StatusNotFound {
+		return inventory, nil, nil
+	}
+	if status != http.StatusOK {
+		return inventory, nil, fmt.Errorf("unable to fetch HP array controllers, status code: %d", status)
+	}
+
+	for _, controllerURL := range controllerURLs {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+controllerURL, nil)
+		if err != nil {
+			return inventory, nil, err
+		}
+
+		var x SmartArrayControllerHP
+		json.Unmarshal(resp, &x)
+
+		if x.SerialNumber != "" {
+			serialNumbers = append(serialNumbers, strings.TrimSpace(x.SerialNumber))
+		}
+
+		controller := StorageControllerData{
+			ID:              x.ID,
+			Name:            x.Location,
+			Model:           x.Model,
+			FirmwareVersion: x.FirmwareVersion.Current.VersionString,
+			CacheSizeMB:     x.CacheMemorySizeMiB,
+			BatteryState:    x.BackupPowerSourceStatus,
+			Mode:            x.CurrentOperatingMode,
+			Health:          x.Status.Health,
+			State:           x.Status.State,
+		}
+		if x.EncryptionEnabled {
+			controller.EncryptionMode = "Enabled"
+		}
+		inventory.controllers = append(inventory.controllers, controller)
+		inventory.health = append(inventory.health, StorageHealthList{
+			Name:   x.Model,
+			Health: x.Status.Health,
+			State:  x.Status.State,
+		})
+
+		if parts&storageVolumesHP != 0 {
+			logicalURLs, _, err := c.getMembersHP(x.Links.LogicalDrives.URL())
+			if err != nil {
+				return inventory, nil, err
+			}
+			for _, logicalURL := range logicalURLs {
+				resp, _, _, err := queryData(c, "GET", c.Hostname+logicalURL, nil)
+				if err != nil {
+					return inventory, nil, err
+				}
+
+				var y SmartArrayLogicalDriveHP
+				json.Unmarshal(resp, &y)
+
+				dataDrives, _, err := c.getMembersHP(y.DataDrives.URL())
+				if err != nil {
+					return inventory, nil, err
+				}
+
+				name := y.LogicalDriveName
+				if name == "" {
+					name = "Logical Drive " + strconv.Itoa(y.LogicalDriveNumber)
+				}
+				inventory.volumes = append(inventory.volumes, StorageVolumeData{
+					ID:              y.ID,
+					Name:            name,
+					ControllerID:    x.ID,
+					RAIDType:        "RAID" + strings.ReplaceAll(y.Raid, "+0", "0"),
+					MediaType:       y.MediaType,
+					DrivesCount:     len(dataDrives),
+					CapacityBytes:   int64(y.CapacityMiB) * 1024 * 1024,
+					StripeSizeBytes: y.StripeSizeBytes,
+					Health:          y.Status.Health,
+					State:           y.Status.State,
+				})
+				inventory.health = append(inventory.health, StorageHealthList{
+					Name:   name,
+					Health: y.Status.Health,
+					State:  y.Status.State,
+					Space:  y.CapacityMiB * 1024 * 1024,
+				})
+			}
+		}
+
+		if parts&storageDrivesHP != 0 {
+			physicalURLs, _, err := c.getMembersHP(x.Links.PhysicalDrives.URL())
+			if err != nil {
+				return inventory, nil, err
+			}
+			for _, physicalURL := range physicalURLs {
+				resp, _, _, err := queryData(c, "GET", c.Hostname+physicalURL, nil)
+				if err != nil {
+					return inventory, nil, err
+				}
+
+				var z SmartArrayPhysicalDriveHP
+				json.Unmarshal(resp, &z)
+
+				driveData := StorageDriveData{
+					ID:                 z.ID,
+					Name:               z.Name,
+					ControllerID:       x.ID,
+					Location:           z.Location,
+					Model:              strings.TrimSpace(z.Model),
+					SerialNumber:       strings.TrimSpace(z.SerialNumber),
+					FirmwareVersion:    z.FirmwareVersion.Current.VersionString,
+					MediaType:          z.MediaType,
+					Protocol:           z.InterfaceType,
+					CapacityBytes:      int64(z.CapacityMiB) * 1024 * 1024,
+					RotationSpeedRPM:   z.RotationalSpeedRpm,
+					Health:             z.Status.Health,
+					State:              z.Status.State,
+					NegotiatedSpeedGbs: float64(z.InterfaceSpeedMbps) / 1000,
+					PowerOnHours:       z.PowerOnHours,
+				}
+				// iLO reports the used endurance of SSDs
+				if z.SSDEnduranceUtilizationPercentage != nil {
+					lifeLeft := 100 - *z.SSDEnduranceUtilizationPercentage
+					driveData.PredictedMediaLifeLeftPercent = &lifeLeft
+				}
+				for _, reason := range z.DiskDriveStatusReasons {
+					if reason != "None" && reason != "OK" {
+						driveData.FailureReason = reason
+						driveData.FailurePredicted = driveData.FailurePredicted || reason == "PredictiveFailure"
+					}
+				}
+				inventory.drives = append(inventory.drives, driveData)
+				inventory.health = append(inventory.health, StorageHealthList{
+					Name:   z.Location,
+					Health: z.Status.Health,
+					State:  z.Status.State,
+					Space:  z.CapacityMiB * 1024 * 1024,
+				})
+			}
+		}
+	}
+
+	return inventory, serialNumbers, nil
+}
+
+// getStandardStorageHP ... will walk the standard Storage resources, skipping the controllers already read from SmartStorage
+func (c *redfishProvider) getStandardStorageHP(parts int, skipSerialNumbers []string) (storageInventoryHP, error) {
+	var inventory storageInventoryHP
+
+	storageURLs, status, err := c.getMembersHP("/redfish/v1/Systems/1/Storage/")
+	if err != nil {
+		return inventory, err
+	}
+	// iLO 4 has no standard Storage resources
+	if status != http.StatusOK {
+		return inventory, nil
+	}
+
+	for _, storageURL := range storageURLs {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+storageURL, nil)
+		if err != nil {
+			return inventory, err
+		}
+
+		var x StorageDetailsDell
+		json.Unmarshal(resp, &x)
+
+		controller := StorageControllerData{
+			ID:     x.ID,
+			Name:   x.Name,
+			Health: x.Status.Health,
+			State:  x.Status.State,
+		}
+		if len(x.StorageControllers) > 0 {
+			if slices.Contains(skipSerialNumbers, strings.TrimSpace(x.StorageControllers[0].SerialNumber)) {
+				continue
+			}
+			controller.Model = x.StorageControllers[0].Model
+			controller.FirmwareVersion = x.StorageControllers[0].FirmwareVersion
+			controller.SupportedRAIDTypes = x.StorageControllers[0].SupportedRAIDTypes
+		}
+		inventory.controllers = append(inventory.controllers, controller)
+		inventory.health = append(inventory.health, StorageHealthList{
+			Name:   x.ID,
+			Health: x.Status.Health,
+			State:  x.Status.State,
+		})
+
+		if parts&storageVolumesHP != 0 {
+			volumeURLs, _, err := c.getMembersHP(strings.TrimSuffix(storageURL, "/") + "/Volumes")
+			if err != nil {
+				return inventory, err
+			}
+			for _, volumeURL := range volumeURLs {
+				resp, _, _, err := queryData(c, "GET", c.Hostname+volumeURL, nil)
+				if err != nil {
+					return inventory, err
+				}
+
+				var y StorageRaidRawDell
+				json.Unmarshal(resp, &y)
+
+				inventory.volumes = append(inventory.volumes, StorageVolumeData{
+					ID:            y.Id,
+					Name:          y.Name,
+					ControllerID:  x.ID,
+					RAIDType:      y.RAIDType,
+					DrivesCount:   y.Links.DrivesCount,
+					CapacityBytes: int64(y.CapacityBytes),
+					Health:        y.Status.Health,
+					State:         y.Status.State,
+				})
+				inventory.health = append(inventory.health, StorageHealthList{
+					Name:   y.Name,
+					Health: y.Status.Health,
+					State:  y.Status.State,
+					Space:  y.CapacityBytes,
+				})
+			}
+		}
+
+		if parts&storageDrivesHP != 0 {
+			for _, drive := range x.Drives {
+				resp, _, _, err := queryData(c, "GET", c.Hostname+drive.OdataId, nil)
+				if err != nil {
+					return inventory, err
+				}
+
+				var z StorageDriveDetailsDell
+				json.Unmarshal(resp, &z)
+
+				driveData, err := standardDriveData(c, z, x.ID)
+				if err != nil {
+					return inventory, err
+				}
+				inventory.drives = append(inventory.drives, driveData)
+				inventory.health = append(inventory.health, StorageHealthList{
+					Name:   z.Name,
+					Health: z.Status.Health,
+					State:  z.Status.State,
+					Space:  z.CapacityBytes,
+				})
+			}
+		}
+	}
+
+	return inventory, nil
+}
+
+// getMembersHP ... will fetch the member links of a collection
+func (c *redfishProvider) getMembersHP(collectionURL string) ([]string, int, error) {
+	if collectionURL == "" {
+		return nil, 0, nil
+	}
+
+	resp, _, status, err := queryData(c, "GET", c.Hostname+collectionURL, nil)
+	if err != nil {
+		return nil, status, err
+	}
+
+	var x MemberCountHP
+	json.Unmarshal(resp, &x)
+
+	var members []string
+	for i := range x.Members {
+		members = append(members, x.Members[i].OdataID)
+	}
+
+	// iLO 4 lists the members of some collections only under links.Member
+	if len(members) == 0 {
+		for i := range x.Links.Member {
+			members = append(members, x.Links.Member[i].Href)
+		}
+	}
+
+	return members, status, nil
+}
+
+//GetStorageControllersHP ... will fetch the Smart Array and standard Storage Controller Details
+func (c *redfishProvider) GetStorageControllersHP() ([]StorageControllerData, error) {
+	inventory, err := c.getStorageInventoryHP(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return inventory.controllers, nil
+}
+
+//GetStorageRaidHP ... will fetch the Logical Drive and Volume Details
+func (c *redfishProvider) GetStorageRaidHP() ([]StorageVolumeData, error) {
+	inventory, err := c.getStorageInventoryHP(storageVolumesHP)
+	if err != nil {
+		return nil, err
+	}
+
+	return inventory.volumes, nil
+}
+
+//GetStorageDrivesHP ... will fetch the Physical Drive Details
+func (c *redfishProvider) GetStorageDrivesHP() ([]StorageDriveData, error) {
+	inventory, err := c.getStorageInventoryHP(storageDrivesHP)
+	if err != nil {
+		return nil, err
+	}
+
+	return inventory.drives, nil
+}
+
+//GetStorageHealthHP ... will fetch the Health of the Controllers, Logical and Physical Drives
+func (c *redfishProvider) GetStorageHealthHP() ([]StorageHealthList, error) {
+	inventory, err := c.getStorageInventoryHP(storageAllHP)
+	if err != nil {
+		return nil, err
+	}
+
+	return inventory.health, nil
+}
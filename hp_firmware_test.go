@@ -0,0 +1,203 @@
+package redfishapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUpdateFirmwareHPSimpleUpdateAndWait(t *testing.T) {
+	fastTaskPolling(t)
+	var (
+		updateHits  atomic.Int32
+		simpleCalls atomic.Int32
+	)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/UpdateService/":
+			switch updateHits.Add(1) {
+			case 1:
+				fmt.Fprint(w, `{"Oem":{"Hpe":{"State":"Idle","FlashProgressPercent":0}}}`)
+			case 2:
+				fmt.Fprint(w, `{"Oem":{"Hpe":{"State":"Writing","FlashProgressPercent":60}}}`)
+			default:
+				fmt.Fprint(w, `{"Oem":{"Hpe":{"State":"Complete","FlashProgressPercent":100}}}`)
+			}
+		case "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate/":
+			simpleCalls.Add(1)
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	taskURL, err := provider.UpdateFirmwareHP("https://repo.example.com/ilo5_278.bin")
+	if err != nil {
+		t.Fatalf("UpdateFirmwareHP returned error: %v", err)
+	}
+	if taskURL != "/redfish/v1/UpdateService/" || simpleCalls.Load() != 1 {
+		t.Fatalf("unexpected task url %q or SimpleUpdate calls %d", taskURL, simpleCalls.Load())
+	}
+
+	var percents []int
+	state, err := provider.WaitForFirmwareUpdateHP(taskURL, time.Minute, func(percent int, message string) {
+		percents = append(percents, percent)
+	})
+	if err != nil {
+		t.Fatalf("WaitForFirmwareUpdateHP returned error: %v", err)
+	}
+	if state != "Complete" || len(percents) != 2 || percents[0] != 60 {
+		t.Fatalf("unexpected state %q or progress %v", state, percents)
+	}
+}
+
+func TestWaitForFirmwareUpdateHPIgnoresIdleBeforeFlash(t *testing.T) {
+	fastTaskPolling(t)
+	var updateHits atomic.Int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch updateHits.Add(1) {
+		case 1, 2:
+			fmt.Fprint(w, `{"Oem":{"Hpe":{"State":"Idle","FlashProgressPercent":0}}}`)
+		case 3:
+			fmt.Fprint(w, `{"Oem":{"Hpe":{"State":"Updating","FlashProgressPercent":40}}}`)
+		default:
+			fmt.Fprint(w, `{"Oem":{"Hpe":{"State":"Idle","FlashProgressPercent":0}}}`)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	state, err := provider.WaitForFirmwareUpdateHP("/redfish/v1/UpdateService/", time.Minute, nil)
+	if err != nil {
+		t.Fatalf("WaitForFirmwareUpdateHP returned error: %v", err)
+	}
+	if state != "Idle" || updateHits.Load() != 4 {
+		t.Fatalf("expected Idle after the flash, got %q after %d polls", state, updateHits.Load())
+	}
+}
+
+func TestWaitForFirmwareUpdateHPAcceptsCompleteWithoutBusyState(t *testing.T) {
+	fastTaskPolling(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Oem":{"Hpe":{"State":"Complete","FlashProgressPercent":100}}}`)
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	state, err := provider.WaitForFirmwareUpdateHP("/redfish/v1/UpdateService/", 10*time.Millisecond, nil)
+	if err != nil || state != "Complete" {
+		t.Fatalf("expected a flash finished between two polls to be Complete, got %q, %v", state, err)
+	}
+}
+
+func TestWaitForFirmwareUpdateHPPollsTaskMonitor(t *testing.T) {
+	fastTaskPolling(t)
+	var taskHits atomic.Int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/TaskService/Tasks/22/":
+			if taskHits.Add(1) == 1 {
+				w.WriteHeader(http.StatusAccepted)
+				fmt.Fprint(w, `{"Id":"22","TaskState":"Running","PercentComplete":40}`)
+				return
+			}
+			fmt.Fprint(w, `{"Id":"22","TaskState":"Completed","TaskStatus":"OK","PercentComplete":100}`)
+		default:
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	state, err := provider.WaitForFirmwareUpdateHP("/redfish/v1/TaskService/Tasks/22/", time.Minute, nil)
+	if err != nil || state != "Completed" || taskHits.Load() != 2 {
+		t.Fatalf("expected the task monitor to be polled until Completed, got %q, %v after %d polls", state, err, taskHits.Load())
+	}
+}
+
+func TestUpdateFirmwareHPRejectsWhileFlashing(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/UpdateService/":
+			fmt.Fprint(w, `{"Oem":{"Hpe":{"State":"Updating","FlashProgressPercent":30}}}`)
+		case "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate/":
+			t.Fatalf("no update should be started while the iLO is flashing")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.UpdateFirmwareHP("https://repo.example.com/ilo5_278.bin"); err == nil {
+		t.Fatalf("expected error while a firmware update is in progress")
+	}
+}
+
+func TestUploadFirmwareHPSendsSessionKeyAndSignature(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "cp045967.exe"), []byte("component"), 0o644)
+	os.WriteFile(filepath.Join(dir, "cp045967.compsig"), []byte("signature"), 0o644)
+
+	var (
+		sessionDeleted atomic.Bool
+		parts          = map[string]string{}
+		cookie         string
+	)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/UpdateService/":
+			fmt.Fprint(w, `{"HttpPushUri":"/cgi-bin/uploadFile","Oem":{"Hpe":{"State":"Idle"}}}`)
+		case r.URL.Path == "/redfish/v1/SessionService/Sessions/" && r.Method == "POST":
+			w.Header().Set("X-Auth-Token", "token123")
+			w.Header().Set("Location", "https://ilo.example.com/redfish/v1/SessionService/Sessions/admin1/")
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Path == "/redfish/v1/SessionService/Sessions/admin1/" && r.Method == "DELETE":
+			sessionDeleted.Store(true)
+		case r.URL.Path == "/cgi-bin/uploadFile":
+			cookie = r.Header.Get("Cookie")
+			if r.ContentLength <= 0 {
+				http.Error(w, "chunked upload", http.StatusLengthRequired)
+				return
+			}
+			r.ParseMultipartForm(1 << 20)
+			parts["sessionKey"] = r.FormValue("sessionKey")
+			parts["parameters"] = r.FormValue("parameters")
+			for _, name := range []string{"compsig", "file"} {
+				if _, header, err := r.FormFile(name); err == nil {
+					parts[name] = header.Filename
+				}
+			}
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.UploadFirmwareHP(dir, "cp045967.exe", false); err != nil {
+		t.Fatalf("UploadFirmwareHP returned error: %v", err)
+	}
+	if cookie != "sessionKey=token123" || parts["sessionKey"] != "token123" {
+		t.Fatalf("unexpected session key, cookie: %q, parts: %+v", cookie, parts)
+	}
+	if parts["compsig"] != "cp045967.compsig" || parts["file"] != "cp045967.exe" || parts["parameters"] == "" {
+		t.Fatalf("unexpected upload parts: %+v", parts)
+	}
+	if !sessionDeleted.Load() {
+		t.Fatalf("expected the session to be deleted")
+	}
+}
@@ -0,0 +1,150 @@
+package redfishapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetStorageHealthHPWalksSmartArray(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/"}]}`)
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/":
+			fmt.Fprint(w, `{"Id":"0","Model":"HPE Smart Array P408i-a SR Gen10","Location":"Slot 0","CacheMemorySizeMiB":2048,"BackupPowerSourceStatus":"Present","CurrentOperatingMode":"Mixed","FirmwareVersion":{"Current":{"VersionString":"3.53"}},"Links":{"LogicalDrives":{"@odata.id":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/LogicalDrives/"},"PhysicalDrives":{"href":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives/"}},"Status":{"Health":"OK","State":"Enabled"}}`)
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/LogicalDrives/":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/LogicalDrives/1/"}]}`)
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/LogicalDrives/1/":
+			fmt.Fprint(w, `{"Id":"1","LogicalDriveNumber":1,"Raid":"1+0","CapacityMiB":1144609,"StripeSizeBytes":262144,"MediaType":"HDD","DataDrives":{"@odata.id":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/LogicalDrives/1/DataDrives/"},"Status":{"Health":"OK","State":"Enabled"}}`)
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/LogicalDrives/1/DataDrives/":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives/0/"},{"@odata.id":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives/1/"}]}`)
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives/":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives/0/"}]}`)
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives/0/":
+			fmt.Fprint(w, `{"Id":"0","Location":"1I:1:1","Model":"EG001200JWJNQ","SerialNumber":"WFK0A1B2","CapacityMiB":1144641,"MediaType":"HDD","InterfaceType":"SAS","RotationalSpeedRpm":10000,"InterfaceSpeedMbps":12000,"PowerOnHours":30112,"DiskDriveStatusReasons":["PredictiveFailure"],"FirmwareVersion":{"Current":{"VersionString":"HPD2"}},"Status":{"Health":"Warning","State":"Enabled"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	health, err := provider.GetStorageHealthHP()
+	if err != nil {
+		t.Fatalf("GetStorageHealthHP returned error: %v", err)
+	}
+	if len(health) != 3 {
+		t.Fatalf("expected controller, logical and physical drive health, got %+v", health)
+	}
+	if health[2].Name != "1I:1:1" || health[2].Health != "Warning" {
+		t.Fatalf("unexpected drive health: %+v", health[2])
+	}
+
+	volumes, err := provider.GetStorageRaidHP()
+	if err != nil {
+		t.Fatalf("GetStorageRaidHP returned error: %v", err)
+	}
+	if len(volumes) != 1 || volumes[0].RAIDType != "RAID10" || volumes[0].DrivesCount != 2 || volumes[0].ControllerID != "0" {
+		t.Fatalf("unexpected volumes: %+v", volumes)
+	}
+
+	drives, err := provider.GetStorageDrivesHP()
+	if err != nil {
+		t.Fatalf("GetStorageDrivesHP returned error: %v", err)
+	}
+	if len(drives) != 1 || drives[0].Protocol != "SAS" || drives[0].FirmwareVersion != "HPD2" || drives[0].CapacityBytes != 1144641*1024*1024 {
+		t.Fatalf("unexpected drives: %+v", drives)
+	}
+	if !drives[0].FailurePredicted || drives[0].FailureReason != "PredictiveFailure" || drives[0].NegotiatedSpeedGbs != 12 || *drives[0].PowerOnHours != 30112 {
+		t.Fatalf("unexpected drives: %+v", drives)
+	}
+}
+
+func TestGetStorageControllersHPMergesStandardStorage(t *testing.T) {
+	var driveHits int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/"}]}`)
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/":
+			fmt.Fprint(w, `{"Id":"0","Model":"HPE Smart Array P408i-a SR Gen10","SerialNumber":"PEYHB0ARHB1234 ","Links":{"LogicalDrives":{"@odata.id":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/LogicalDrives/"},"PhysicalDrives":{"href":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives/"}},"Status":{"Health":"OK","State":"Enabled"}}`)
+		case "/redfish/v1/Systems/1/Storage/":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/1/Storage/DA000000"},{"@odata.id":"/redfish/v1/Systems/1/Storage/DE00C000"}]}`)
+		case "/redfish/v1/Systems/1/Storage/DA000000":
+			fmt.Fprint(w, `{"Id":"DA000000","StorageControllers":[{"Model":"HPE Smart Array P408i-a SR Gen10","SerialNumber":"PEYHB0ARHB1234"}],"Status":{"Health":"OK","State":"Enabled"}}`)
+		case "/redfish/v1/Systems/1/Storage/DE00C000":
+			fmt.Fprint(w, `{"Id":"DE00C000","StorageControllers":[{"Model":"NVMe Controller","SerialNumber":"S4YNNE0N123456"}],"Drives":[{"@odata.id":"/redfish/v1/Systems/1/Storage/DE00C000/Drives/0"}],"Status":{"Health":"OK","State":"Enabled"}}`)
+		default:
+			if strings.Contains(r.URL.Path, "Drives") {
+				driveHits++
+			}
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	controllers, err := provider.GetStorageControllersHP()
+	if err != nil {
+		t.Fatalf("GetStorageControllersHP returned error: %v", err)
+	}
+	if len(controllers) != 2 || controllers[0].ID != "0" || controllers[1].ID != "DE00C000" {
+		t.Fatalf("expected the Smart Array and the NVMe controller, got %+v", controllers)
+	}
+	if driveHits != 0 {
+		t.Fatalf("expected no drive requests for the controllers, got %d", driveHits)
+	}
+}
+
+func TestGetStorageControllersHPFallsBackToStandardStorage(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/1/Storage/":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Systems/1/Storage/DE00A000"}]}`)
+		case "/redfish/v1/Systems/1/Storage/DE00A000":
+			fmt.Fprint(w, `{"Id":"DE00A000","Name":"HPE MR408i-o Gen11","StorageControllers":[{"Model":"HPE MR408i-o Gen11","FirmwareVersion":"52.24.3-4948","SupportedRAIDTypes":["RAID0","RAID1"]}],"Status":{"Health":"OK","State":"Enabled"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	controllers, err := provider.GetStorageControllersHP()
+	if err != nil {
+		t.Fatalf("GetStorageControllersHP returned error: %v", err)
+	}
+	if len(controllers) != 1 || controllers[0].Model != "HPE MR408i-o Gen11" || controllers[0].FirmwareVersion != "52.24.3-4948" {
+		t.Fatalf("unexpected controllers: %+v", controllers)
+	}
+}
+
+func TestGetStorageDrivesHPReadsILO4CollectionLinks(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/":
+			fmt.Fprint(w, `{"Total":1,"links":{"Member":[{"href":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/"}]}}`)
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/":
+			fmt.Fprint(w, `{"Id":"0","Model":"Smart Array P440ar Controller","Location":"Slot 0","FirmwareVersion":{"Current":{"VersionString":"6.88"}},"Links":{"PhysicalDrives":{"href":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives/"}},"Status":{"Health":"OK","State":"Enabled"}}`)
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives/":
+			fmt.Fprint(w, `{"Total":1,"links":{"Member":[{"href":"/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives/0/"}]}}`)
+		case "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/0/DiskDrives/0/":
+			fmt.Fprint(w, `{"Id":"0","Location":"1I:1:1","Model":"EG0600FBVFP","CapacityMiB":572325,"MediaType":"HDD","InterfaceType":"SAS","Status":{"Health":"OK","State":"Enabled"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	drives, err := provider.GetStorageDrivesHP()
+	if err != nil {
+		t.Fatalf("GetStorageDrivesHP returned error: %v", err)
+	}
+	if len(drives) != 1 || drives[0].Location != "1I:1:1" || drives[0].ControllerID != "0" {
+		t.Fatalf("unexpected drives: %+v", drives)
+	}
+}
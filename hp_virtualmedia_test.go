@@ -0,0 +1,116 @@
+package redfishapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInsertVirtualMediaHPUsesSlotForMediaType(t *testing.T) {
+	var (
+		target  string
+		payload map[string]interface{}
+	)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/Managers/1/VirtualMedia/":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Managers/1/VirtualMedia/1/"},{"@odata.id":"/redfish/v1/Managers/1/VirtualMedia/2/"}]}`)
+		case r.URL.Path == "/redfish/v1/Managers/1/VirtualMedia/1/" && r.Method == "GET":
+			fmt.Fprint(w, `{"@odata.id":"/redfish/v1/Managers/1/VirtualMedia/1/","Id":"1","MediaTypes":["Floppy","USBStick"],"Actions":{"#VirtualMedia.InsertMedia":{"target":"/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.InsertMedia/"},"#VirtualMedia.EjectMedia":{"target":"/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.EjectMedia/"}}}`)
+		case r.URL.Path == "/redfish/v1/Managers/1/VirtualMedia/2/" && r.Method == "GET":
+			fmt.Fprint(w, `{"@odata.id":"/redfish/v1/Managers/1/VirtualMedia/2/","Id":"2","MediaTypes":["CD","DVD"],"Image":"","Inserted":false,"Actions":{"#VirtualMedia.InsertMedia":{"target":"/redfish/v1/Managers/1/VirtualMedia/2/Actions/VirtualMedia.InsertMedia/"},"#VirtualMedia.EjectMedia":{"target":"/redfish/v1/Managers/1/VirtualMedia/2/Actions/VirtualMedia.EjectMedia/"}},"Oem":{"Hpe":{"BootOnNextServerReset":false,"Actions":{"#HpeiLOVirtualMedia.InsertVirtualMedia":{"target":"/redfish/v1/Managers/1/VirtualMedia/2/Actions/Oem/Hpe/HpeiLOVirtualMedia.InsertVirtualMedia/"}}}}}`)
+		default:
+			target = r.Method + " " + r.URL.Path
+			body, _ := io.ReadAll(r.Body)
+			payload = nil
+			json.Unmarshal(body, &payload)
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	media, err := provider.GetVirtualMediaHP()
+	if err != nil {
+		t.Fatalf("GetVirtualMediaHP returned error: %v", err)
+	}
+	if len(media) != 2 || media[1].Id != "2" || media[1].MediaTypes[0] != "CD" {
+		t.Fatalf("unexpected virtual media: %+v", media)
+	}
+
+	slot, err := provider.InsertVirtualMediaHP(VirtualMediaRequest{
+		Image:                "https://images.example.com/rhel9.iso",
+		MediaType:            "dvd",
+		Username:             "reader",
+		Password:             "secret",
+		TransferProtocolType: "https",
+	})
+	if err != nil {
+		t.Fatalf("InsertVirtualMediaHP returned error: %v", err)
+	}
+	if slot != "2" || target != "POST /redfish/v1/Managers/1/VirtualMedia/2/Actions/VirtualMedia.InsertMedia/" {
+		t.Fatalf("unexpected slot %q or target %q", slot, target)
+	}
+	if payload["UserName"] != "reader" || payload["TransferProtocolType"] != "HTTPS" || payload["Inserted"] != true || payload["WriteProtected"] != true {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+
+	if _, err := provider.InsertVirtualMediaHP(VirtualMediaRequest{Image: "https://images.example.com/boot.img", MediaType: "usbstick", Writable: true}); err != nil {
+		t.Fatalf("InsertVirtualMediaHP returned error: %v", err)
+	}
+	if target != "POST /redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.InsertMedia/" || payload["WriteProtected"] != false {
+		t.Fatalf("unexpected target %q or payload for USBStick: %+v", target, payload)
+	}
+
+	if _, err := provider.SetVirtualMediaBootHP("2", true); err != nil {
+		t.Fatalf("SetVirtualMediaBootHP returned error: %v", err)
+	}
+	if target != "PATCH /redfish/v1/Managers/1/VirtualMedia/2/" || payload["Oem"].(map[string]interface{})["Hpe"].(map[string]interface{})["BootOnNextServerReset"] != true {
+		t.Fatalf("unexpected boot request %q: %+v", target, payload)
+	}
+
+	if _, err := provider.EjectVirtualMediaHP(""); err != nil {
+		t.Fatalf("EjectVirtualMediaHP returned error: %v", err)
+	}
+	if target != "POST /redfish/v1/Managers/1/VirtualMedia/2/Actions/VirtualMedia.EjectMedia/" {
+		t.Fatalf("unexpected eject target: %q", target)
+	}
+}
+
+func TestInsertVirtualMediaHPFallsBackToOemAction(t *testing.T) {
+	var target string
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/Managers/1/VirtualMedia/":
+			fmt.Fprint(w, `{"Members":[{"@odata.id":"/redfish/v1/Managers/1/VirtualMedia/2/"}]}`)
+		case r.URL.Path == "/redfish/v1/Managers/1/VirtualMedia/2/" && r.Method == "GET":
+			fmt.Fprint(w, `{"Id":"2","MediaTypes":["CD","DVD"],"Oem":{"Hp":{"Actions":{"#HpiLOVirtualMedia.InsertVirtualMedia":{"target":"/redfish/v1/Managers/1/VirtualMedia/2/Actions/Oem/Hp/HpiLOVirtualMedia.InsertVirtualMedia/"}}}}}`)
+		default:
+			target = r.Method + " " + r.URL.Path
+		}
+	}))
+	defer server.Close()
+
+	provider := &redfishProvider{Hostname: server.URL, Username: "user", Password: "pass"}
+	if _, err := provider.InsertVirtualMediaHP(VirtualMediaRequest{Image: "http://images.example.com/spp.iso", Username: "reader"}); err == nil {
+		t.Fatalf("expected error for credentials on an iLO 4 slot")
+	}
+
+	if _, err := provider.InsertVirtualMediaHP(VirtualMediaRequest{Image: "http://images.example.com/spp.iso"}); err != nil {
+		t.Fatalf("InsertVirtualMediaHP returned error: %v", err)
+	}
+	if target != "POST /redfish/v1/Managers/1/VirtualMedia/2/Actions/Oem/Hp/HpiLOVirtualMedia.InsertVirtualMedia/" {
+		t.Fatalf("unexpected target: %q", target)
+	}
+
+	if _, err := provider.EjectVirtualMediaHP("2"); err != nil {
+		t.Fatalf("EjectVirtualMediaHP returned error: %v", err)
+	}
+	if target != "PATCH /redfish/v1/Managers/1/VirtualMedia/2/" {
+		t.Fatalf("unexpected eject target: %q", target)
+	}
+}
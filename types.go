@@ -1,5 +1,13 @@
 package redfishapi
 
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+)
+
 //Dell Based Structs
 
 // SysAttrDell ... System Attributes from the Redfish API
@@ -2001,24 +2009,45 @@ type BootOrderDell struct {
 	_odata_type       string
 	AttributeRegistry string `json:"AttributeRegistry"`
 	Attributes        struct {
-		BootSeq []struct {
-			Enabled bool   `json:"Enabled"`
-			ID      string `json:"Id"`
-			Index   int    `json:"Index"`
-			Name    string `json:"Name"`
-		} `json:"BootSeq"`
-		UefiBootSeq []struct {
-			Enabled bool   `json:"Enabled"`
-			ID      string `json:"Id"`
-			Index   int    `json:"Index"`
-			Name    string `json:"Name"`
-		} `json:"UefiBootSeq"`
+		BootSeq     []BootSeqEntryDell `json:"BootSeq"`
+		UefiBootSeq []BootSeqEntryDell `json:"UefiBootSeq"`
 	} `json:"Attributes"`
 	Description string `json:"Description"`
 	ID          string `json:"Id"`
 	Name        string `json:"Name"`
 }
 
+// BootSeqEntryDell ... Single BootSeq/UefiBootSeq entry of the Dell Boot Sources
+type BootSeqEntryDell struct {
+	Enabled bool   `json:"Enabled"`
+	ID      string `json:"Id"`
+	Index   int    `json:"Index"`
+	Name    string `json:"Name"`
+}
+
+// BootOptionRaw ... Fetch a member of the standard BootOptions collection from the Redfish API
+type BootOptionRaw struct {
+	OdataID             string `json:"@odata.id"`
+	Alias               string `json:"Alias"`
+	BootOptionEnabled   bool   `json:"BootOptionEnabled"`
+	BootOptionReference string `json:"BootOptionReference"`
+	Description         string `json:"Description"`
+	DisplayName         string `json:"DisplayName"`
+	ID                  string `json:"Id"`
+	Name                string `json:"Name"`
+	UefiDevicePath      string `json:"UefiDevicePath"`
+}
+
+// SystemBootOrder ... Fetch the standard Boot.BootOrder of a ComputerSystem from the Redfish API
+type SystemBootOrder struct {
+	Boot struct {
+		BootOptions struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"BootOptions"`
+		BootOrder []string `json:"BootOrder"`
+	} `json:"Boot"`
+}
+
 // FirmwareDataDell ...
 type FirmwareDataDell struct {
 	_odata_context string
@@ -2228,6 +2257,237 @@ type PowerDataDell struct {
 	Voltagescount int `json:"Voltages@odata.count"`
 }
 
+// PowerTelemetryRaw ... Fetch the readings of the standard Chassis Power resource from the Redfish API
+type PowerTelemetryRaw struct {
+	// iLO 4 before 2.30 reports the readings at the top level only
+	PowerCapacityWatts float64 `json:"PowerCapacityWatts"`
+	PowerConsumedWatts float64 `json:"PowerConsumedWatts"`
+	PowerMetrics       struct {
+		AverageConsumedWatts float64 `json:"AverageConsumedWatts"`
+		IntervalInMin        int     `json:"IntervalInMin"`
+		MaxConsumedWatts     float64 `json:"MaxConsumedWatts"`
+		MinConsumedWatts     float64 `json:"MinConsumedWatts"`
+	} `json:"PowerMetrics"`
+	PowerControl []struct {
+		MemberID            string  `json:"MemberId"`
+		Name                string  `json:"Name"`
+		PowerAllocatedWatts float64 `json:"PowerAllocatedWatts"`
+		PowerAvailableWatts float64 `json:"PowerAvailableWatts"`
+		PowerCapacityWatts  float64 `json:"PowerCapacityWatts"`
+		PowerConsumedWatts  float64 `json:"PowerConsumedWatts"`
+		PowerRequestedWatts float64 `json:"PowerRequestedWatts"`
+		PowerLimit          struct {
+			CorrectionInMs int      `json:"CorrectionInMs"`
+			LimitException string   `json:"LimitException"`
+			LimitInWatts   *float64 `json:"LimitInWatts"`
+		} `json:"PowerLimit"`
+		PowerMetrics struct {
+			AverageConsumedWatts float64 `json:"AverageConsumedWatts"`
+			IntervalInMin        int     `json:"IntervalInMin"`
+			MaxConsumedWatts     float64 `json:"MaxConsumedWatts"`
+			MinConsumedWatts     float64 `json:"MinConsumedWatts"`
+		} `json:"PowerMetrics"`
+	} `json:"PowerControl"`
+	PowerSupplies []struct {
+		FirmwareVersion      string  `json:"FirmwareVersion"`
+		LastPowerOutputWatts float64 `json:"LastPowerOutputWatts"`
+		LineInputVoltage     float64 `json:"LineInputVoltage"`
+		LineInputVoltageType string  `json:"LineInputVoltageType"`
+		MemberID             string  `json:"MemberId"`
+		Model                string  `json:"Model"`
+		Name                 string  `json:"Name"`
+		PowerCapacityWatts   float64 `json:"PowerCapacityWatts"`
+		PowerInputWatts      float64 `json:"PowerInputWatts"`
+		PowerOutputWatts     float64 `json:"PowerOutputWatts"`
+		SerialNumber         string  `json:"SerialNumber"`
+		Status               struct {
+			Health string `json:"Health"`
+			State  string `json:"State"`
+		} `json:"Status"`
+	} `json:"PowerSupplies"`
+}
+
+// ThermalTelemetryRaw ... Fetch the readings of the standard Chassis Thermal resource from the Redfish API
+type ThermalTelemetryRaw struct {
+	Fans []struct {
+		CurrentReading            *float64 `json:"CurrentReading"`
+		FanName                   string   `json:"FanName"`
+		LowerThresholdCritical    *float64 `json:"LowerThresholdCritical"`
+		LowerThresholdFatal       *float64 `json:"LowerThresholdFatal"`
+		LowerThresholdNonCritical *float64 `json:"LowerThresholdNonCritical"`
+		MemberID                  string   `json:"MemberId"`
+		Name                      string   `json:"Name"`
+		PhysicalContext           string   `json:"PhysicalContext"`
+		Reading                   *float64 `json:"Reading"`
+		ReadingUnits              string   `json:"ReadingUnits"`
+		Status                    struct {
+			Health string `json:"Health"`
+			State  string `json:"State"`
+		} `json:"Status"`
+		Units                     string   `json:"Units"`
+		UpperThresholdCritical    *float64 `json:"UpperThresholdCritical"`
+		UpperThresholdFatal       *float64 `json:"UpperThresholdFatal"`
+		UpperThresholdNonCritical *float64 `json:"UpperThresholdNonCritical"`
+	} `json:"Fans"`
+	Temperatures []struct {
+		CurrentReading            *float64 `json:"CurrentReading"`
+		LowerThresholdCritical    *float64 `json:"LowerThresholdCritical"`
+		LowerThresholdFatal       *float64 `json:"LowerThresholdFatal"`
+		LowerThresholdNonCritical *float64 `json:"LowerThresholdNonCritical"`
+		MemberID                  string   `json:"MemberId"`
+		Name                      string   `json:"Name"`
+		PhysicalContext           string   `json:"PhysicalContext"`
+		ReadingCelsius            *float64 `json:"ReadingCelsius"`
+		Status                    struct {
+			Health string `json:"Health"`
+			State  string `json:"State"`
+		} `json:"Status"`
+		UpperThresholdCritical    *float64 `json:"UpperThresholdCritical"`
+		UpperThresholdFatal       *float64 `json:"UpperThresholdFatal"`
+		UpperThresholdNonCritical *float64 `json:"UpperThresholdNonCritical"`
+	} `json:"Temperatures"`
+}
+
+// PCIeDeviceLinksRaw ... Fetch the PCIe Device links of a System from the Redfish API
+type PCIeDeviceLinksRaw struct {
+	PCIeDevices []Members `json:"PCIeDevices"`
+}
+
+// PCIeDeviceRaw ... Fetch a PCIe Device from the Redfish API
+type PCIeDeviceRaw struct {
+	DeviceType      string `json:"DeviceType"`
+	FirmwareVersion string `json:"FirmwareVersion"`
+	ID              string `json:"Id"`
+	Links           struct {
+		PCIeFunctions []Members `json:"PCIeFunctions"`
+	} `json:"Links"`
+	Manufacturer  string `json:"Manufacturer"`
+	Model         string `json:"Model"`
+	Name          string `json:"Name"`
+	PartNumber    string `json:"PartNumber"`
+	PCIeFunctions struct {
+		OdataID string `json:"@odata.id"`
+	} `json:"PCIeFunctions"`
+	PCIeInterface struct {
+		LanesInUse  int    `json:"LanesInUse"`
+		MaxLanes    int    `json:"MaxLanes"`
+		MaxPCIeType string `json:"MaxPCIeType"`
+		PCIeType    string `json:"PCIeType"`
+	} `json:"PCIeInterface"`
+	SerialNumber string `json:"SerialNumber"`
+	Slot         struct {
+		Location struct {
+			PartLocation struct {
+				LocationOrdinalValue *int   `json:"LocationOrdinalValue"`
+				ServiceLabel         string `json:"ServiceLabel"`
+			} `json:"PartLocation"`
+		} `json:"Location"`
+	} `json:"Slot"`
+	Status struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+// PCIeFunctionRaw ... Fetch a PCIe Function from the Redfish API
+type PCIeFunctionRaw struct {
+	ClassCode    string `json:"ClassCode"`
+	DeviceClass  string `json:"DeviceClass"`
+	DeviceID     string `json:"DeviceId"`
+	FunctionID   int    `json:"FunctionId"`
+	FunctionType string `json:"FunctionType"`
+	ID           string `json:"Id"`
+	Name         string `json:"Name"`
+	RevisionID   string `json:"RevisionId"`
+	Status       struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+	SubsystemID       string `json:"SubsystemId"`
+	SubsystemVendorID string `json:"SubsystemVendorId"`
+	VendorID          string `json:"VendorId"`
+}
+
+// MemoryRaw ... Fetch a member of the System Memory collection from the Redfish API
+type MemoryRaw struct {
+	CapacityMiB      int    `json:"CapacityMiB"`
+	DeviceLocator    string `json:"DeviceLocator"`
+	DIMMStatus       string `json:"DIMMStatus"`
+	DIMMType         string `json:"DIMMType"`
+	ErrorCorrection  string `json:"ErrorCorrection"`
+	ID               string `json:"Id"`
+	Manufacturer     string `json:"Manufacturer"`
+	MaximumFrequency int    `json:"MaximumFrequencyMHz"`
+	MemoryDeviceType string `json:"MemoryDeviceType"`
+	MemoryLocation   struct {
+		Channel int `json:"Channel"`
+		Slot    int `json:"Slot"`
+		Socket  int `json:"Socket"`
+	} `json:"MemoryLocation"`
+	Metrics struct {
+		OdataID string `json:"@odata.id"`
+	} `json:"Metrics"`
+	Name string `json:"Name"`
+	Oem  struct {
+		Hpe struct {
+			DIMMStatus string `json:"DIMMStatus"`
+		} `json:"Hpe"`
+	} `json:"Oem"`
+	OperatingSpeedMhz int    `json:"OperatingSpeedMhz"`
+	PartNumber        string `json:"PartNumber"`
+	SerialNumber      string `json:"SerialNumber"`
+	SizeMB            int    `json:"SizeMB"`
+	SocketLocator     string `json:"SocketLocator"`
+	Status            struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+// MemoryMetricsRaw ... Fetch the ECC error counters of a DIMM from the Redfish API
+type MemoryMetricsRaw struct {
+	CurrentPeriod struct {
+		CorrectableECCErrorCount   *int64 `json:"CorrectableECCErrorCount"`
+		UncorrectableECCErrorCount *int64 `json:"UncorrectableECCErrorCount"`
+	} `json:"CurrentPeriod"`
+	LifeTime struct {
+		CorrectableECCErrorCount   *int64 `json:"CorrectableECCErrorCount"`
+		UncorrectableECCErrorCount *int64 `json:"UncorrectableECCErrorCount"`
+	} `json:"LifeTime"`
+}
+
+// DriveMetricsRaw ... Fetch the Drive Metrics from the Redfish API
+type DriveMetricsRaw struct {
+	PowerOnHours *float64 `json:"PowerOnHours"`
+}
+
+// SensorRaw ... Fetch a member of the Chassis Sensors collection from the Redfish API
+type SensorRaw struct {
+	ID              string   `json:"Id"`
+	Name            string   `json:"Name"`
+	PhysicalContext string   `json:"PhysicalContext"`
+	Reading         *float64 `json:"Reading"`
+	ReadingType     string   `json:"ReadingType"`
+	ReadingUnits    string   `json:"ReadingUnits"`
+	Status          struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+	Thresholds struct {
+		LowerCaution  SensorThresholdRaw `json:"LowerCaution"`
+		LowerCritical SensorThresholdRaw `json:"LowerCritical"`
+		LowerFatal    SensorThresholdRaw `json:"LowerFatal"`
+		UpperCaution  SensorThresholdRaw `json:"UpperCaution"`
+		UpperCritical SensorThresholdRaw `json:"UpperCritical"`
+		UpperFatal    SensorThresholdRaw `json:"UpperFatal"`
+	} `json:"Thresholds"`
+}
+
+// SensorThresholdRaw ...
+type SensorThresholdRaw struct {
+	Reading *float64 `json:"Reading"`
+}
+
 // AccountsInfoDell ...
 type AccountsInfoDell struct {
 	_odata_context string
@@ -2422,6 +2682,14 @@ type ThermalHealthListDell struct {
 	Temperaturescount int `json:"Temperatures@odata.count"`
 }
 
+// RemoteAPIStatusDell ... Response of DellLCService.GetRemoteServicesAPIStatus
+type RemoteAPIStatusDell struct {
+	LCStatus     string `json:"LCStatus"`
+	RTStatus     string `json:"RTStatus"`
+	ServerStatus string `json:"ServerStatus"`
+	Status       string `json:"Status"`
+}
+
 // MemberCountDell ...
 type MemberCountDell struct {
 	OdataContext        string    `json:"@odata.context"`
@@ -2554,16 +2822,22 @@ type StorageDetailsDell struct {
 				CacheSizeInMB             int         `json:"CacheSizeInMB"`
 				CachecadeCapability       string      `json:"CachecadeCapability"`
 				ControllerFirmwareVersion string      `json:"ControllerFirmwareVersion"`
+				CurrentControllerMode     string      `json:"CurrentControllerMode"`
 				DeviceCardSlotType        string      `json:"DeviceCardSlotType"`
 				DriverVersion             interface{} `json:"DriverVersion"`
 				EncryptionCapability      string      `json:"EncryptionCapability"`
 				EncryptionMode            string      `json:"EncryptionMode"`
 				PCISlot                   int         `json:"PCISlot"`
 				PatrolReadState           string      `json:"PatrolReadState"`
+				PersonalityMode           string      `json:"PersonalityMode"`
 				RollupStatus              string      `json:"RollupStatus"`
 				SecurityStatus            string      `json:"SecurityStatus"`
 				SlicedVDCapability        string      `json:"SlicedVDCapability"`
 			} `json:"DellController"`
+			DellControllerBattery struct {
+				PrimaryStatus string `json:"PrimaryStatus"`
+				RAIDState     string `json:"RAIDState"`
+			} `json:"DellControllerBattery"`
 		} `json:"Dell"`
 	} `json:"Oem"`
 	Status struct {
@@ -2587,6 +2861,7 @@ type StorageDetailsDell struct {
 		MemberID     string   `json:"MemberId"`
 		Model        string   `json:"Model"`
 		Name         string   `json:"Name"`
+		SerialNumber string   `json:"SerialNumber"`
 		SpeedGbps    int      `json:"SpeedGbps"`
 		Status       struct {
 			Health       string `json:"Health"`
@@ -2595,6 +2870,7 @@ type StorageDetailsDell struct {
 		} `json:"Status"`
 		SupportedControllerProtocols []string `json:"SupportedControllerProtocols"`
 		SupportedDeviceProtocols     []string `json:"SupportedDeviceProtocols"`
+		SupportedRAIDTypes           []string `json:"SupportedRAIDTypes"`
 	} `json:"StorageControllers"`
 	StorageControllers_odata_count int `json:"StorageControllers@odata.count"`
 	Volumes                        struct {
@@ -2631,13 +2907,17 @@ type StorageDriveDetailsDell struct {
 		Volumes             []interface{} `json:"Volumes"`
 		Volumes_odata_count int           `json:"Volumes@odata.count"`
 	} `json:"Links"`
-	Location           []interface{} `json:"Location"`
-	Manufacturer       string        `json:"Manufacturer"`
-	MediaType          string        `json:"MediaType"`
-	Model              string        `json:"Model"`
-	Name               string        `json:"Name"`
-	NegotiatedSpeedGbs int           `json:"NegotiatedSpeedGbs"`
-	Oem                struct {
+	Location     []interface{} `json:"Location"`
+	Manufacturer string        `json:"Manufacturer"`
+	MediaType    string        `json:"MediaType"`
+	Metrics      struct {
+		OdataId string `json:"@odata.id"`
+	} `json:"Metrics"`
+	Model                   string  `json:"Model"`
+	Name                    string  `json:"Name"`
+	NegotiatedSpeedGbs      int     `json:"NegotiatedSpeedGbs"`
+	NegotiatedSpeedGbsFloat float64 `json:"-"`
+	Oem                     struct {
 		Dell struct {
 			DellPhysicalDisk struct {
 				_odata_context         string
@@ -2672,6 +2952,23 @@ type StorageDriveDetailsDell struct {
 	} `json:"Status"`
 }
 
+// driveDetailsDell ... StorageDriveDetailsDell without its UnmarshalJSON
+type driveDetailsDell StorageDriveDetailsDell
+
+// UnmarshalJSON ... will keep NegotiatedSpeedGbs for callers and read the fractional speed, e.g. 22.5, into NegotiatedSpeedGbsFloat
+func (d *StorageDriveDetailsDell) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		*driveDetailsDell
+		NegotiatedSpeedGbs float64 `json:"NegotiatedSpeedGbs"`
+	}{driveDetailsDell: (*driveDetailsDell)(d)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	d.NegotiatedSpeedGbs = int(raw.NegotiatedSpeedGbs)
+	d.NegotiatedSpeedGbsFloat = raw.NegotiatedSpeedGbs
+	return nil
+}
+
 //HP Based Structs
 
 // FirmwareInventoryHP ...
@@ -2826,9 +3123,12 @@ type SystemInfoHP struct {
 	} `json:"Bios"`
 	BiosVersion string `json:"BiosVersion"`
 	Boot        struct {
-		BootSourceOverrideEnabled   string   `json:"BootSourceOverrideEnabled"`
-		BootSourceOverrideSupported []string `json:"BootSourceOverrideSupported"`
-		BootSourceOverrideTarget    string   `json:"BootSourceOverrideTarget"`
+		BootSourceOverrideEnabled                        string   `json:"BootSourceOverrideEnabled"`
+		BootSourceOverrideMode                           string   `json:"BootSourceOverrideMode"`
+		BootSourceOverrideSupported                      []string `json:"BootSourceOverrideSupported"`
+		BootSourceOverrideTarget                         string   `json:"BootSourceOverrideTarget"`
+		BootSourceOverrideTarget_Redfish_AllowableValues []string `json:"BootSourceOverrideTarget@Redfish.AllowableValues"`
+		UefiTargetBootSourceOverride                     string   `json:"UefiTargetBootSourceOverride"`
 	} `json:"Boot"`
 	Description     string `json:"Description"`
 	HostCorrelation struct {
@@ -3006,6 +3306,34 @@ type SystemInfoHP struct {
 	} `json:"links"`
 }
 
+// BootSettingsHP ... Fetch the persistent boot order from the iLO Bios Boot resource
+type BootSettingsHP struct {
+	OdataID     string `json:"@odata.id"`
+	BootSources []struct {
+		BootString           string `json:"BootString"`
+		CorrelatableID       string `json:"CorrelatableID"`
+		StructuredBootString string `json:"StructuredBootString"`
+		UEFIDevicePath       string `json:"UEFIDevicePath"`
+	} `json:"BootSources"`
+	DefaultBootOrder          []string      `json:"DefaultBootOrder"`
+	DesiredBootDevices        []interface{} `json:"DesiredBootDevices"`
+	PersistentBootConfigOrder []string      `json:"PersistentBootConfigOrder"`
+}
+
+// ManagerInfoHP ... Fetch the iLO Manager resource from the Redfish API
+type ManagerInfoHP struct {
+	OdataID         string `json:"@odata.id"`
+	FirmwareVersion string `json:"FirmwareVersion"`
+	ID              string `json:"Id"`
+	ManagerType     string `json:"ManagerType"`
+	Model           string `json:"Model"`
+	Name            string `json:"Name"`
+	Status          struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
 // MemberCountHP ...
 type MemberCountHP struct {
 	OdataContext string `json:"@odata.context"`
@@ -3013,10 +3341,15 @@ type MemberCountHP struct {
 	OdataType    string `json:"@odata.type"`
 	Description  string `json:"Description"`
 	Members      []struct {
-		OdataID string
+		OdataID string `json:"@odata.id"`
 	} `json:"Members"`
 	Members_odata_count int    `json:"Members@odata.count"`
 	Name                string `json:"Name"`
+	Links               struct {
+		Member []struct {
+			Href string `json:"href"`
+		} `json:"Member"`
+	} `json:"links"`
 }
 
 // ThermalHealthListHP ...
@@ -3320,7 +3653,9 @@ type ProcessorInfoHP struct {
 	MaxSpeedMHz    int64  `json:"MaxSpeedMHz"`
 	Model          string `json:"Model"`
 	Name           string `json:"Name"`
-	Oem            struct {
+	// OperatingSpeedMHz is only reported by newer iLO firmware
+	OperatingSpeedMHz int64 `json:"OperatingSpeedMHz"`
+	Oem               struct {
 		Hp struct {
 			OdataType string `json:"@odata.type"`
 			AssetTag  string `json:"AssetTag"`
@@ -3725,6 +4060,136 @@ type BiosAttrHP struct {
 	} `json:"links"`
 }
 
+// UpdateServiceHP ... Update Service Details from the Redfish API
+type UpdateServiceHP struct {
+	HTTPPushURI          string `json:"HttpPushUri"`
+	MultipartHTTPPushURI string `json:"MultipartHttpPushUri"`
+	Oem                  struct {
+		Hp struct {
+			ProgressPercent int    `json:"ProgressPercent"`
+			State           string `json:"State"`
+		} `json:"Hp"`
+		Hpe struct {
+			FlashProgressPercent int `json:"FlashProgressPercent"`
+			Result               struct {
+				MessageID string `json:"MessageId"`
+			} `json:"Result"`
+			State string `json:"State"`
+		} `json:"Hpe"`
+	} `json:"Oem"`
+}
+
+// UpdateTaskHP ... Update Task Queue entry from the Redfish API
+type UpdateTaskHP struct {
+	Command  string `json:"Command"`
+	Filename string `json:"Filename"`
+	ID       string `json:"Id"`
+	Name     string `json:"Name"`
+	OdataID  string `json:"@odata.id"`
+	Result   struct {
+		MessageID string `json:"MessageId"`
+	} `json:"Result"`
+	State       string   `json:"State"`
+	UpdatableBy []string `json:"UpdatableBy"`
+}
+
+// InstallSetHP ... Install Set Details from the Redfish API
+type InstallSetHP struct {
+	Description string `json:"Description"`
+	ID          string `json:"Id"`
+	IsRecovery  bool   `json:"IsRecovery"`
+	Name        string `json:"Name"`
+	OdataID     string `json:"@odata.id"`
+	Sequence    []struct {
+		Command     string   `json:"Command"`
+		Filename    string   `json:"Filename"`
+		Name        string   `json:"Name"`
+		UpdatableBy []string `json:"UpdatableBy"`
+	} `json:"Sequence"`
+}
+
+// SmartStorageLinkHP ... iLO 5 links with @odata.id and iLO 4 with href
+type SmartStorageLinkHP struct {
+	OdataID string `json:"@odata.id"`
+	Href    string `json:"href"`
+}
+
+// URL ... Check SmartStorageLinkHP for the iLO 5 or the iLO 4 link
+func (link SmartStorageLinkHP) URL() string {
+	if link.OdataID != "" {
+		return link.OdataID
+	}
+	return link.Href
+}
+
+// SmartArrayControllerHP ... Smart Array Controller Details from the Redfish API
+type SmartArrayControllerHP struct {
+	BackupPowerSourceStatus string `json:"BackupPowerSourceStatus"`
+	CacheMemorySizeMiB      int    `json:"CacheMemorySizeMiB"`
+	CurrentOperatingMode    string `json:"CurrentOperatingMode"`
+	EncryptionEnabled       bool   `json:"EncryptionEnabled"`
+	FirmwareVersion         struct {
+		Current struct {
+			VersionString string `json:"VersionString"`
+		} `json:"Current"`
+	} `json:"FirmwareVersion"`
+	ID    string `json:"Id"`
+	Links struct {
+		LogicalDrives  SmartStorageLinkHP `json:"LogicalDrives"`
+		PhysicalDrives SmartStorageLinkHP `json:"PhysicalDrives"`
+	} `json:"Links"`
+	Location     string `json:"Location"`
+	Model        string `json:"Model"`
+	Name         string `json:"Name"`
+	SerialNumber string `json:"SerialNumber"`
+	Status       struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+// SmartArrayLogicalDriveHP ... Smart Array Logical Drive Details from the Redfish API
+type SmartArrayLogicalDriveHP struct {
+	CapacityMiB        int                `json:"CapacityMiB"`
+	DataDrives         SmartStorageLinkHP `json:"DataDrives"`
+	ID                 string             `json:"Id"`
+	LogicalDriveName   string             `json:"LogicalDriveName"`
+	LogicalDriveNumber int                `json:"LogicalDriveNumber"`
+	MediaType          string             `json:"MediaType"`
+	Raid               string             `json:"Raid"`
+	Status             struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+	StripeSizeBytes int `json:"StripeSizeBytes"`
+}
+
+// SmartArrayPhysicalDriveHP ... Smart Array Physical Drive Details from the Redfish API
+type SmartArrayPhysicalDriveHP struct {
+	CapacityMiB     int `json:"CapacityMiB"`
+	FirmwareVersion struct {
+		Current struct {
+			VersionString string `json:"VersionString"`
+		} `json:"Current"`
+	} `json:"FirmwareVersion"`
+	DiskDriveStatusReasons            []string `json:"DiskDriveStatusReasons"`
+	ID                                string   `json:"Id"`
+	InterfaceSpeedMbps                int      `json:"InterfaceSpeedMbps"`
+	InterfaceType                     string   `json:"InterfaceType"`
+	Location                          string   `json:"Location"`
+	MediaType                         string   `json:"MediaType"`
+	Model                             string   `json:"Model"`
+	Name                              string   `json:"Name"`
+	PowerOnHours                      *float64 `json:"PowerOnHours"`
+	RotationalSpeedRpm                int      `json:"RotationalSpeedRpm"`
+	SerialNumber                      string   `json:"SerialNumber"`
+	SSDEnduranceUtilizationPercentage *float64 `json:"SSDEnduranceUtilizationPercentage"`
+	Status                            struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
 // LicenseInfoHP ... License Details from the Redfish API
 type LicenseInfoHP struct {
 	OdataContext string `json:"@odata.context"`
@@ -3771,33 +4236,11 @@ type PCISlotsInfoHP struct {
 	OdataContext string
 	OdataID      string
 	OdataType    string
-	Description  string `json:"Description"`
-	Items        []struct {
-		OdataContext string `json:"@odata.context"`
-		OdataID      string `json:"@odata.id"`
-		OdataType    string `json:"@odata.type"`
-		ID           string `json:"Id"`
-		Length       string `json:"Length"`
-		LinkLanes    string `json:"LinkLanes"`
-		Name         string `json:"Name"`
-		Status       struct {
-			OperationalStatus []struct {
-				Status string `json:"Status"`
-			} `json:"OperationalStatus"`
-		} `json:"Status"`
-		SupportsHotPlug bool   `json:"SupportsHotPlug"`
-		Technology      string `json:"Technology"`
-		Type            string `json:"Type"`
-		UEFIDevicePath  string `json:"UEFIDevicePath"`
-		Links           struct {
-			Self struct {
-				Href string `json:"href"`
-			} `json:"self"`
-		} `json:"links"`
-	} `json:"Items"`
-	MemberType string `json:"MemberType"`
-	Members    []struct {
-		OdataID string
+	Description  string      `json:"Description"`
+	Items        []PCISlotHP `json:"Items"`
+	MemberType   string      `json:"MemberType"`
+	Members      []struct {
+		OdataID string `json:"@odata.id"`
 	} `json:"Members"`
 	Members_odata_count int    `json:"Members@odata.count"`
 	Name                string `json:"Name"`
@@ -3813,6 +4256,32 @@ type PCISlotsInfoHP struct {
 	} `json:"links"`
 }
 
+// PCISlotHP ... PCI Slot Details from the Redfish API
+type PCISlotHP struct {
+	OdataContext string `json:"@odata.context"`
+	OdataID      string `json:"@odata.id"`
+	OdataType    string `json:"@odata.type"`
+	ID           string `json:"Id"`
+	Length       string `json:"Length"`
+	LinkLanes    string `json:"LinkLanes"`
+	Name         string `json:"Name"`
+	Status       struct {
+		Health            string `json:"Health"`
+		OperationalStatus []struct {
+			Status string `json:"Status"`
+		} `json:"OperationalStatus"`
+	} `json:"Status"`
+	SupportsHotPlug bool   `json:"SupportsHotPlug"`
+	Technology      string `json:"Technology"`
+	Type            string `json:"Type"`
+	UEFIDevicePath  string `json:"UEFIDevicePath"`
+	Links           struct {
+		Self struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
 //Custom Structs
 
 // HealthList ...
@@ -3912,6 +4381,10 @@ type StorageRaidRawDell struct {
 			} `json:"DellVirtualDisk"`
 		} `json:"Dell"`
 	} `json:"Oem"`
+	Operations []struct {
+		OperationName      string `json:"OperationName"`
+		PercentageComplete int    `json:"PercentageComplete"`
+	} `json:"Operations"`
 	OperationsCount    int    `json:"Operations@odata.count"`
 	OptimumIOSizeBytes int    `json:"OptimumIOSizeBytes"`
 	RAIDType           string `json:"RAIDType"`
@@ -3937,6 +4410,198 @@ type StorageRaidDetailsDell struct {
 	WriteCachePolicy string `json:"writecachepolicy"`
 }
 
+// StorageControllerData ...
+type StorageControllerData struct {
+	ID                   string   `json:"id"`
+	Name                 string   `json:"name"`
+	Model                string   `json:"model"`
+	FirmwareVersion      string   `json:"firmware_version"`
+	CacheSizeMB          int      `json:"cache_size_mb"`
+	BatteryState         string   `json:"battery_state"`
+	Mode                 string   `json:"mode"`
+	SupportedRAIDTypes   []string `json:"supported_raid_types"`
+	EncryptionCapability string   `json:"encryption_capability"`
+	EncryptionMode       string   `json:"encryption_mode"`
+	SecurityStatus       string   `json:"security_status"`
+	Health               string   `json:"health"`
+	State                string   `json:"state"`
+}
+
+// StorageDriveData ...
+type StorageDriveData struct {
+	ID                            string   `json:"id"`
+	Name                          string   `json:"name"`
+	ControllerID                  string   `json:"controller_id"`
+	Location                      string   `json:"location"`
+	Model                         string   `json:"model"`
+	SerialNumber                  string   `json:"serial_number"`
+	FirmwareVersion               string   `json:"firmware_version"`
+	MediaType                     string   `json:"media_type"`
+	Protocol                      string   `json:"protocol"`
+	CapacityBytes                 int64    `json:"capacity_bytes"`
+	RotationSpeedRPM              int      `json:"rotation_speed_rpm"`
+	Health                        string   `json:"health"`
+	State                         string   `json:"state"`
+	NegotiatedSpeedGbs            float64  `json:"negotiated_speed_gbs"`
+	FailurePredicted              bool     `json:"failure_predicted"`
+	FailureReason                 string   `json:"failure_reason"`
+	PredictedMediaLifeLeftPercent *float64 `json:"predicted_media_life_left_percent,omitempty"`
+	PowerOnHours                  *float64 `json:"power_on_hours,omitempty"`
+}
+
+// StorageVolumeData ...
+type StorageVolumeData struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	ControllerID    string `json:"controller_id"`
+	RAIDType        string `json:"raid_type"`
+	MediaType       string `json:"media_type"`
+	DrivesCount     int    `json:"drives_count"`
+	CapacityBytes   int64  `json:"capacity_bytes"`
+	StripeSizeBytes int    `json:"stripe_size_bytes"`
+	Health          string `json:"health"`
+	State           string `json:"state"`
+}
+
+// percentCompleteDell ... percentage the iDRACs report as a number or as a string
+type percentCompleteDell float64
+
+// UnmarshalJSON ... will accept 42, 42.5 and "42.5"
+func (p *percentCompleteDell) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseFloat(strings.Trim(strings.TrimSpace(string(data)), `"`), 64)
+	if err != nil {
+		*p = 0
+		return nil
+	}
+	*p = percentCompleteDell(value)
+	return nil
+}
+
+// virtualDiskOperationDell ... progress of the running operation in the OEM section of a Volume
+type virtualDiskOperationDell struct {
+	Oem struct {
+		Dell struct {
+			DellVirtualDisk struct {
+				OperationPercentComplete percentCompleteDell `json:"OperationPercentComplete"`
+			} `json:"DellVirtualDisk"`
+		} `json:"Dell"`
+	} `json:"Oem"`
+}
+
+// StorageOperationData ...
+type StorageOperationData struct {
+	VolumeID        string `json:"volume_id"`
+	VolumeName      string `json:"volume_name"`
+	ControllerID    string `json:"controller_id"`
+	Operation       string `json:"operation"`
+	PercentComplete int    `json:"percent_complete"`
+}
+
+// VolumeRequest ... describes the virtual disk to create on a storage controller
+type VolumeRequest struct {
+	ControllerID     string   `json:"controller_id"`
+	Name             string   `json:"name"`
+	RAIDType         string   `json:"raid_type"`
+	Drives           []string `json:"drives"`
+	CapacityBytes    int64    `json:"capacity_bytes"`
+	StripeSizeBytes  int      `json:"stripe_size_bytes"`
+	ReadCachePolicy  string   `json:"read_cache_policy"`
+	WriteCachePolicy string   `json:"write_cache_policy"`
+}
+
+// RepositoryDell ... network share holding a Dell update catalog
+// ShareType: HTTP, HTTPS, NFS or CIFS, CatalogFile defaults to Catalog.xml
+type RepositoryDell struct {
+	IPAddress         string `json:"ip_address"`
+	ShareType         string `json:"share_type"`
+	ShareName         string `json:"share_name"`
+	CatalogFile       string `json:"catalog_file"`
+	Username          string `json:"username"`
+	Password          string `json:"password"`
+	ApplyUpdate       bool   `json:"apply_update"`
+	RebootNeeded      bool   `json:"reboot_needed"`
+	IgnoreCertWarning bool   `json:"ignore_cert_warning"`
+}
+
+// RollbackVersionData ... previous firmware kept by the iDRAC for a component
+type RollbackVersionData struct {
+	Name             string `json:"name"`
+	Id               string `json:"id"`
+	ComponentID      string `json:"component_id"`
+	InstalledVersion string `json:"installed_version"`
+	RollbackVersion  string `json:"rollback_version"`
+	OdataId          string `json:"odata_id"`
+}
+
+// FirmwareUpdateStep ... one update of a firmware plan, the image is uploaded from FirmwareDir/FirmwareFile or fetched from ImageURI
+// Component is a regular expression matched against the firmware inventory id and name to read the installed version,
+// ManagerReboot marks updates which restart the iDRAC, iDRAC and Lifecycle Controller steps always do
+type FirmwareUpdateStep struct {
+	Name            string `json:"name"`
+	Component       string `json:"component"`
+	ImageURI        string `json:"image_uri"`
+	FirmwareDir     string `json:"firmware_dir"`
+	FirmwareFile    string `json:"firmware_file"`
+	ExpectedVersion string `json:"expected_version"`
+	ManagerReboot   bool   `json:"manager_reboot"`
+}
+
+// FirmwarePlanDell ... ordered firmware updates, use OrderFirmwareStepsDell for the iDRAC, BIOS, NIC/RAID order
+type FirmwarePlanDell struct {
+	Steps           []FirmwareUpdateStep `json:"steps"`
+	StepTimeout     time.Duration        `json:"step_timeout"`
+	ManagerTimeout  time.Duration        `json:"manager_timeout"`
+	ContinueOnError bool                 `json:"continue_on_error"`
+	RebootHost      bool                 `json:"reboot_host"`
+}
+
+// FirmwareStepReport ...
+type FirmwareStepReport struct {
+	Name            string    `json:"name"`
+	JobID           string    `json:"job_id"`
+	JobState        string    `json:"job_state"`
+	VersionBefore   string    `json:"version_before"`
+	VersionAfter    string    `json:"version_after"`
+	ExpectedVersion string    `json:"expected_version"`
+	Verified        bool      `json:"verified"`
+	Status          string    `json:"status"`
+	Error           string    `json:"error,omitempty"`
+	Started         time.Time `json:"started"`
+	Finished        time.Time `json:"finished"`
+}
+
+// FirmwareUpdateReport ...
+type FirmwareUpdateReport struct {
+	Steps    []FirmwareStepReport `json:"steps"`
+	Success  bool                 `json:"success"`
+	Started  time.Time            `json:"started"`
+	Finished time.Time            `json:"finished"`
+}
+
+// RepoUpdateDell ... component that would be updated from the repository catalog
+type RepoUpdateDell struct {
+	Name             string `json:"name"`
+	ComponentID      string `json:"component_id"`
+	ComponentType    string `json:"component_type"`
+	InstalledVersion string `json:"installed_version"`
+	PackageVersion   string `json:"package_version"`
+	PackageName      string `json:"package_name"`
+	PackagePath      string `json:"package_path"`
+	Criticality      string `json:"criticality"`
+	RebootType       string `json:"reboot_type"`
+	JobID            string `json:"job_id"`
+}
+
+// repoUpdateListXMLDell ... CIM document returned in the PackageList of GetRepoBasedUpdateList
+type repoUpdateListXMLDell struct {
+	Instances []struct {
+		Properties []struct {
+			Name  string `xml:"NAME,attr"`
+			Value string `xml:"VALUE"`
+		} `xml:"PROPERTY"`
+	} `xml:"MESSAGE>SIMPLEREQ>VALUE.NAMEDINSTANCE>INSTANCE"`
+}
+
 // MACData ...
 type MACData struct {
 	MacAddress           string `json:"macaddress"`
@@ -3989,6 +4654,104 @@ type BootOrderData struct {
 	ID      string `json:"id"`
 }
 
+// LCStatusData ...
+type LCStatusData struct {
+	LCStatus     string `json:"lc_status"`
+	RTStatus     string `json:"rt_status"`
+	ServerStatus string `json:"server_status"`
+	Status       string `json:"status"`
+}
+
+// IsReady ... Check LCStatusData whether the Lifecycle Controller accepts jobs
+func (lcStatus LCStatusData) IsReady() bool {
+	if lcStatus.Status != "" {
+		return lcStatus.Status == "Ready"
+	}
+	return lcStatus.LCStatus == "Ready"
+}
+
+// PowerMetricsData ...
+type PowerMetricsData struct {
+	PowerConsumedWatts   float64           `json:"power_consumed_watts"`
+	PowerCapacityWatts   float64           `json:"power_capacity_watts"`
+	AverageConsumedWatts float64           `json:"average_consumed_watts"`
+	MinConsumedWatts     float64           `json:"min_consumed_watts"`
+	MaxConsumedWatts     float64           `json:"max_consumed_watts"`
+	IntervalInMin        int               `json:"interval_in_min"`
+	PowerLimitEnabled    bool              `json:"power_limit_enabled"`
+	PowerLimitWatts      float64           `json:"power_limit_watts"`
+	PowerLimitException  string            `json:"power_limit_exception"`
+	PowerSupplies        []PowerSupplyData `json:"power_supplies"`
+}
+
+// PowerSupplyData ...
+type PowerSupplyData struct {
+	Name                 string  `json:"name"`
+	Model                string  `json:"model"`
+	SerialNumber         string  `json:"serial_number"`
+	FirmwareVersion      string  `json:"firmware_version"`
+	Health               string  `json:"health"`
+	State                string  `json:"state"`
+	PowerCapacityWatts   float64 `json:"power_capacity_watts"`
+	PowerInputWatts      float64 `json:"power_input_watts"`
+	PowerOutputWatts     float64 `json:"power_output_watts"`
+	LineInputVoltage     float64 `json:"line_input_voltage"`
+	LineInputVoltageType string  `json:"line_input_voltage_type"`
+}
+
+// MemoryData ...
+type MemoryData struct {
+	Slot                string `json:"slot"`
+	Name                string `json:"name"`
+	CapacityMiB         int    `json:"capacity_mib"`
+	SpeedMHz            int    `json:"speed_mhz"`
+	Manufacturer        string `json:"manufacturer"`
+	PartNumber          string `json:"part_number"`
+	SerialNumber        string `json:"serial_number"`
+	MemoryType          string `json:"memory_type"`
+	ErrorCorrection     string `json:"error_correction"`
+	Health              string `json:"health"`
+	State               string `json:"state"`
+	CorrectableErrors   *int64 `json:"correctable_errors,omitempty"`
+	UncorrectableErrors *int64 `json:"uncorrectable_errors,omitempty"`
+}
+
+// ThermalReadingData ...
+type ThermalReadingData struct {
+	Name                      string   `json:"name"`
+	Type                      string   `json:"type"`
+	Reading                   *float64 `json:"reading"`
+	Units                     string   `json:"units"`
+	PhysicalContext           string   `json:"physical_context"`
+	Health                    string   `json:"health"`
+	State                     string   `json:"state"`
+	UpperThresholdNonCritical *float64 `json:"upper_threshold_non_critical,omitempty"`
+	UpperThresholdCritical    *float64 `json:"upper_threshold_critical,omitempty"`
+	UpperThresholdFatal       *float64 `json:"upper_threshold_fatal,omitempty"`
+	LowerThresholdNonCritical *float64 `json:"lower_threshold_non_critical,omitempty"`
+	LowerThresholdCritical    *float64 `json:"lower_threshold_critical,omitempty"`
+	LowerThresholdFatal       *float64 `json:"lower_threshold_fatal,omitempty"`
+}
+
+// BootOptionData ...
+type BootOptionData struct {
+	ID             string `json:"id"`
+	Reference      string `json:"reference"`
+	DisplayName    string `json:"display_name"`
+	Enabled        bool   `json:"enabled"`
+	UefiDevicePath string `json:"uefi_device_path"`
+	Position       int    `json:"position"`
+}
+
+// BootOverrideData ...
+type BootOverrideData struct {
+	Target           string   `json:"target"`
+	Enabled          string   `json:"enabled"`
+	Mode             string   `json:"mode"`
+	UefiTarget       string   `json:"uefi_target"`
+	AllowableTargets []string `json:"allowable_targets"`
+}
+
 // IDRACData ...
 type IDRACData struct {
 	VirtualConsoleMaxSessions int    `json:"virtualconsole_maxsessions"`
@@ -4232,10 +4995,64 @@ type LicenseInfo struct {
 
 // PCISlotsInfo ...
 type PCISlotsInfo struct {
-	Name       string `json:"name"`
-	Status     string `json:"status"`
-	Technology string `json:"technology"`
-	Type       string `json:"type"`
+	Name            string `json:"name"`
+	Status          string `json:"status"`
+	Technology      string `json:"technology"`
+	Type            string `json:"type"`
+	Length          string `json:"length"`
+	LinkLanes       string `json:"link_lanes"`
+	SupportsHotPlug bool   `json:"supports_hot_plug"`
+}
+
+// ProcessorInfo ...
+type ProcessorInfo struct {
+	Socket          string `json:"socket"`
+	Manufacturer    string `json:"manufacturer"`
+	Model           string `json:"model"`
+	Cores           int    `json:"cores"`
+	Threads         int    `json:"threads"`
+	MaxSpeedMHz     int    `json:"max_speed_mhz"`
+	CurrentSpeedMHz int    `json:"current_speed_mhz"`
+	Microcode       string `json:"microcode"`
+	Health          string `json:"health"`
+	State           string `json:"state"`
+}
+
+// PCIeDeviceData ...
+type PCIeDeviceData struct {
+	ID              string             `json:"id"`
+	Name            string             `json:"name"`
+	Slot            string             `json:"slot"`
+	Manufacturer    string             `json:"manufacturer"`
+	Model           string             `json:"model"`
+	DeviceType      string             `json:"device_type"`
+	SerialNumber    string             `json:"serial_number"`
+	PartNumber      string             `json:"part_number"`
+	FirmwareVersion string             `json:"firmware_version"`
+	PCIeType        string             `json:"pcie_type"`
+	MaxPCIeType     string             `json:"max_pcie_type"`
+	LanesInUse      int                `json:"lanes_in_use"`
+	MaxLanes        int                `json:"max_lanes"`
+	Health          string             `json:"health"`
+	State           string             `json:"state"`
+	Functions       []PCIeFunctionData `json:"functions"`
+}
+
+// PCIeFunctionData ...
+type PCIeFunctionData struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	FunctionID        int    `json:"function_id"`
+	FunctionType      string `json:"function_type"`
+	DeviceClass       string `json:"device_class"`
+	ClassCode         string `json:"class_code"`
+	VendorID          string `json:"vendor_id"`
+	DeviceID          string `json:"device_id"`
+	SubsystemVendorID string `json:"subsystem_vendor_id"`
+	SubsystemID       string `json:"subsystem_id"`
+	RevisionID        string `json:"revision_id"`
+	Health            string `json:"health"`
+	State             string `json:"state"`
 }
 
 // ExportConfigStatus
@@ -4251,6 +5068,16 @@ type ExportConfigStatus struct {
 		MessageArgs             []interface{} `json:"MessageArgs"`
 		MessageArgs_odata_count int           `json:"MessageArgs@odata.count"`
 		MessageID               string        `json:"MessageId"`
+		Oem                     struct {
+			Dell struct {
+				ErrCode  int    `json:"ErrCode"`
+				FQDD     string `json:"FQDD"`
+				Name     string `json:"Name"`
+				NewValue string `json:"NewValue"`
+				OldValue string `json:"OldValue"`
+				Status   string `json:"Status"`
+			} `json:"Dell"`
+		} `json:"Oem"`
 	} `json:"Messages"`
 	Messages_odata_count int    `json:"Messages@odata.count"`
 	Name                 string `json:"Name"`
@@ -4278,6 +5105,92 @@ type ExportConfigStatus struct {
 	PercentComplete int    `json:"PercentComplete"`
 }
 
+// SCPAttributeResult ... Per attribute result of a Server Configuration Profile import or preview
+type SCPAttributeResult struct {
+	FQDD      string `json:"fqdd"`
+	Name      string `json:"name"`
+	OldValue  string `json:"old_value"`
+	NewValue  string `json:"new_value"`
+	Status    string `json:"status"`
+	ErrCode   int    `json:"err_code"`
+	Message   string `json:"message"`
+	MessageID string `json:"message_id"`
+}
+
+// SCPImportResult ... Return Response for a Server Configuration Profile import or preview
+type SCPImportResult struct {
+	TaskID     string               `json:"task_id"`
+	TaskState  string               `json:"task_state"`
+	TaskStatus string               `json:"task_status"`
+	Message    string               `json:"message"`
+	Results    []SCPAttributeResult `json:"results"`
+}
+
+// SCPAttributeDiff ... Difference of one attribute between two Server Configuration Profiles
+type SCPAttributeDiff struct {
+	FQDD     string `json:"fqdd"`
+	Name     string `json:"name"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+	Change   string `json:"change"`
+}
+
+// FirmwareBaseline ... approved firmware version for the components matching the pattern
+// Component is a regular expression matched against the firmware id and name,
+// Models limits the rule to these system models and applies to all when empty
+type FirmwareBaseline struct {
+	Component    string   `json:"component"`
+	MinVersion   string   `json:"min_version,omitempty"`
+	ExactVersion string   `json:"exact_version,omitempty"`
+	Models       []string `json:"models,omitempty"`
+}
+
+// FirmwareComplianceData ...
+type FirmwareComplianceData struct {
+	Name             string `json:"name"`
+	Id               string `json:"id"`
+	InstalledVersion string `json:"installed_version"`
+	ExpectedVersion  string `json:"expected_version"`
+	Status           string `json:"status"`
+}
+
+// catalogXMLDell ... Dell Catalog.xml, only the parts needed to build a firmware baseline
+type catalogXMLDell struct {
+	SoftwareComponents []struct {
+		DellVersion   string `xml:"dellVersion,attr"`
+		VendorVersion string `xml:"vendorVersion,attr"`
+		Path          string `xml:"path,attr"`
+		Name          string `xml:"Name>Display"`
+		Devices       []struct {
+			ComponentID string `xml:"componentID,attr"`
+			Name        string `xml:"Display"`
+		} `xml:"SupportedDevices>Device"`
+		Models []struct {
+			SystemID string `xml:"systemID,attr"`
+			Name     string `xml:"Display"`
+		} `xml:"SupportedSystems>Brand>Model"`
+	} `xml:"SoftwareComponent"`
+}
+
+// scpXMLDell ... Server Configuration Profile in the XML layout accepted by the ImportBuffer
+type scpXMLDell struct {
+	XMLName    xml.Name              `xml:"SystemConfiguration"`
+	Components []scpXMLComponentDell `xml:"Component"`
+}
+
+type scpXMLComponentDell struct {
+	FQDD       string                `xml:"FQDD,attr"`
+	Attributes []scpXMLAttributeDell `xml:"Attribute"`
+}
+
+type scpXMLAttributeDell struct {
+	Name  string `xml:"Name,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ProgressFunc ... Callback for the percent complete and message of a running task or job
+type ProgressFunc func(percent int, message string)
+
 type ExportConfigResponse struct {
 	SystemConfiguration struct {
 		Comments []struct {
@@ -4311,6 +5224,18 @@ type JobResponseDell struct {
 	} `json:"@Message.ExtendedInfo"`
 }
 
+// ErrorResponseDell ... error body of a rejected request
+type ErrorResponseDell struct {
+	Error struct {
+		MessageExtendedInfo []struct {
+			Message    string `json:"Message"`
+			MessageID  string `json:"MessageId"`
+			Resolution string `json:"Resolution"`
+		} `json:"@Message.ExtendedInfo"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
 type JobStatusDell struct {
 	OdataContext   string
 	OdataID        string
@@ -4389,3 +5314,69 @@ type ImageStatusDell struct {
 	Name                   string   `json:"Name"`
 	WriteProtected         bool     `json:"WriteProtected"`
 }
+
+// VirtualMediaRaw ... VirtualMedia slot of the iDRAC or the iLO, iLO 4 and 5 also list OEM insert and eject actions
+type VirtualMediaRaw struct {
+	OdataId              string   `json:"@odata.id"`
+	Id                   string   `json:"Id"`
+	Name                 string   `json:"Name"`
+	Image                string   `json:"Image"`
+	ImageName            string   `json:"ImageName"`
+	Inserted             bool     `json:"Inserted"`
+	WriteProtected       bool     `json:"WriteProtected"`
+	MediaTypes           []string `json:"MediaTypes"`
+	ConnectedVia         string   `json:"ConnectedVia"`
+	TransferProtocolType string   `json:"TransferProtocolType"`
+	UserName             string   `json:"UserName"`
+	Actions              map[string]struct {
+		Target string `json:"target"`
+	} `json:"Actions"`
+	Oem struct {
+		Hpe virtualMediaOemHP `json:"Hpe"`
+		Hp  virtualMediaOemHP `json:"Hp"`
+	} `json:"Oem"`
+}
+
+// virtualMediaOemHP ...
+type virtualMediaOemHP struct {
+	BootOnNextServerReset bool `json:"BootOnNextServerReset"`
+	Actions               map[string]struct {
+		Target string `json:"target"`
+	} `json:"Actions"`
+}
+
+// VirtualMediaData ...
+type VirtualMediaData struct {
+	Id                    string   `json:"id"`
+	Name                  string   `json:"name"`
+	Image                 string   `json:"image"`
+	ImageName             string   `json:"image_name"`
+	Inserted              bool     `json:"inserted"`
+	WriteProtected        bool     `json:"write_protected"`
+	MediaTypes            []string `json:"media_types"`
+	ConnectedVia          string   `json:"connected_via"`
+	TransferProtocolType  string   `json:"transfer_protocol_type"`
+	BootOnNextServerReset bool     `json:"boot_on_next_server_reset"`
+}
+
+// VirtualMediaRequest ... image to insert, Slot is the VirtualMedia id and when empty the first slot supporting MediaType is used
+// MediaType: CD, DVD, Floppy or USBStick, defaults to CD
+// TransferProtocolType: CIFS, FTP, SFTP, HTTP, HTTPS, NFS, SCP or TFTP
+type VirtualMediaRequest struct {
+	Image                string `json:"image"`
+	Slot                 string `json:"slot"`
+	MediaType            string `json:"media_type"`
+	Username             string `json:"username"`
+	Password             string `json:"password"`
+	TransferProtocolType string `json:"transfer_protocol_type"`
+	// Writable mounts the image read-write, images are write protected by default
+	Writable bool `json:"writable"`
+}
+
+// storageInventoryHP ... Smart Array or standard Storage inventory mapped to the common types
+type storageInventoryHP struct {
+	controllers []StorageControllerData
+	volumes     []StorageVolumeData
+	drives      []StorageDriveData
+	health      []StorageHealthList
+}